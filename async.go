@@ -0,0 +1,230 @@
+package logrus
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// AsyncOverflow decides what happens when the queue of an asynchronous logger is full.
+type AsyncOverflow int
+
+const (
+	// OverflowBlock blocks the logging goroutine until there is room in the queue
+	OverflowBlock AsyncOverflow = iota
+	// OverflowDropNewest drops the entry being logged
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest queued entry to make room
+	OverflowDropOldest
+	// OverflowDropBelow drops the entry being logged when it is less severe than
+	// Async.DropBelow and blocks otherwise
+	OverflowDropBelow
+)
+
+// DefaultAsyncBufferSize is the queue size used when Async.BufferSize is not set.
+const DefaultAsyncBufferSize = 1024
+
+// Async configures asynchronous output, entries are queued and written by a
+// background goroutine so that slow outputs do not block the logging goroutines.
+type Async struct {
+	// BufferSize is the number of entries that can be queued, DefaultAsyncBufferSize when 0
+	BufferSize int
+
+	// Overflow decides what happens when the queue is full
+	Overflow AsyncOverflow
+
+	// DropBelow is the least severe level that is not dropped with OverflowDropBelow
+	DropBelow Level
+}
+
+// asyncRecord is a queued record and the handler it has to be written to.
+type asyncRecord struct {
+	ctx     context.Context
+	record  slog.Record
+	handler slog.Handler
+}
+
+// asyncWriter is a bounded ring buffer of records drained by a goroutine.
+type asyncWriter struct {
+	mu        sync.Mutex
+	notEmpty  *sync.Cond
+	notFull   *sync.Cond
+	idle      *sync.Cond
+	queue     []asyncRecord
+	head      int
+	count     int
+	busy      bool
+	closed    bool
+	overflow  AsyncOverflow
+	dropBelow Level
+	dropped   atomic.Uint64
+	done      chan struct{}
+}
+
+// SetAsync enables asynchronous output, passing nil disables it. Entries queued under a
+// previous configuration are written before the call returns.
+func (logger *Logger) SetAsync(async *Async) {
+	var a *asyncWriter
+	if async != nil {
+		a = newAsyncWriter(async)
+	}
+
+	if previous := logger.async.Swap(a); previous != nil {
+		previous.close()
+	}
+}
+
+// SetAsync enables asynchronous output on the standard logger.
+func SetAsync(async *Async) {
+	standardLogger.SetAsync(async)
+}
+
+// AsyncDropped returns the number of entries dropped because the queue was full.
+func (logger *Logger) AsyncDropped() uint64 {
+	a := logger.async.Load()
+	if a == nil {
+		return 0
+	}
+	return a.dropped.Load()
+}
+
+// Flush logs the summaries of repeats held by deduplication and waits until all queued
+// entries have been written. It is called before exiting on fatal and panic entries.
+func (logger *Logger) Flush() {
+	if d := logger.deduper.Load(); d != nil {
+		d.flush()
+	}
+	if a := logger.async.Load(); a != nil {
+		a.flush()
+	}
+}
+
+// Flush logs everything held by the standard logger.
+func Flush() {
+	standardLogger.Flush()
+}
+
+// Close flushes the logger and stops its background goroutines, it disables
+// asynchronous output and deduplication.
+func (logger *Logger) Close() error {
+	logger.SetDeduplication(nil)
+	logger.SetAsync(nil)
+	return nil
+}
+
+func newAsyncWriter(async *Async) *asyncWriter {
+	size := async.BufferSize
+	if size <= 0 {
+		size = DefaultAsyncBufferSize
+	}
+
+	a := &asyncWriter{
+		queue:     make([]asyncRecord, size),
+		overflow:  async.Overflow,
+		dropBelow: async.DropBelow,
+		done:      make(chan struct{}),
+	}
+	a.notEmpty = sync.NewCond(&a.mu)
+	a.notFull = sync.NewCond(&a.mu)
+	a.idle = sync.NewCond(&a.mu)
+
+	go a.run()
+
+	return a
+}
+
+// enqueue queues r for writing to handler, applying the overflow policy when full.
+func (a *asyncWriter) enqueue(ctx context.Context, r slog.Record, handler slog.Handler) {
+	a.mu.Lock()
+
+	for !a.closed && a.count == len(a.queue) {
+		switch a.overflow {
+		case OverflowDropNewest:
+			a.dropped.Add(1)
+			a.mu.Unlock()
+			return
+
+		case OverflowDropOldest:
+			a.queue[a.head] = asyncRecord{}
+			a.head = (a.head + 1) % len(a.queue)
+			a.count--
+			a.dropped.Add(1)
+
+		case OverflowDropBelow:
+			if levelFromSlog(r.Level) > a.dropBelow {
+				a.dropped.Add(1)
+				a.mu.Unlock()
+				return
+			}
+			a.notFull.Wait()
+
+		default:
+			a.notFull.Wait()
+		}
+	}
+
+	if a.closed {
+		// the writer was replaced while logging, write synchronously
+		a.mu.Unlock()
+		handler.Handle(ctx, r)
+		return
+	}
+
+	a.queue[(a.head+a.count)%len(a.queue)] = asyncRecord{ctx: ctx, record: r.Clone(), handler: handler}
+	a.count++
+	a.notEmpty.Signal()
+	a.mu.Unlock()
+}
+
+// run writes queued records until the writer is closed and the queue is empty.
+func (a *asyncWriter) run() {
+	defer close(a.done)
+
+	a.mu.Lock()
+	for {
+		for a.count == 0 && !a.closed {
+			a.notEmpty.Wait()
+		}
+		if a.count == 0 {
+			a.mu.Unlock()
+			return
+		}
+
+		item := a.queue[a.head]
+		a.queue[a.head] = asyncRecord{}
+		a.head = (a.head + 1) % len(a.queue)
+		a.count--
+		a.busy = true
+		a.notFull.Signal()
+		a.mu.Unlock()
+
+		item.handler.Handle(item.ctx, item.record)
+
+		a.mu.Lock()
+		a.busy = false
+		if a.count == 0 {
+			a.idle.Broadcast()
+		}
+	}
+}
+
+// flush waits until all queued records have been written.
+func (a *asyncWriter) flush() {
+	a.mu.Lock()
+	for a.count > 0 || a.busy {
+		a.idle.Wait()
+	}
+	a.mu.Unlock()
+}
+
+// close writes all queued records and stops the goroutine.
+func (a *asyncWriter) close() {
+	a.mu.Lock()
+	a.closed = true
+	a.notEmpty.Broadcast()
+	a.notFull.Broadcast()
+	a.mu.Unlock()
+
+	<-a.done
+}
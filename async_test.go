@@ -0,0 +1,116 @@
+package logrus
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// blockingWriter blocks all writes until release is closed, started is closed by the first write.
+type blockingWriter struct {
+	syncBuffer
+	once    sync.Once
+	started chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.started) })
+	<-w.release
+	return w.syncBuffer.Write(p)
+}
+
+func TestAsyncFlush(t *testing.T) {
+	var buf syncBuffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetAsync(&Async{BufferSize: 16})
+	defer logger.Close()
+
+	for i := 0; i < 100; i++ {
+		logger.WithField("n", i).Info("async message")
+	}
+	logger.Flush()
+
+	if n := strings.Count(buf.String(), "async message"); n != 100 {
+		t.Errorf("Expected 100 messages after Flush, got %d", n)
+	}
+	if dropped := logger.AsyncDropped(); dropped != 0 {
+		t.Errorf("AsyncDropped() = %d, want 0 with the blocking policy", dropped)
+	}
+}
+
+func TestAsyncOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		async    Async
+		dropped  uint64
+		expected []string
+		missing  []string
+	}{
+		{"drop newest", Async{BufferSize: 2, Overflow: OverflowDropNewest}, 2,
+			[]string{"msg=m0", "msg=m1", "msg=m2"}, []string{"msg=m3", "msg=m4"}},
+		{"drop oldest", Async{BufferSize: 2, Overflow: OverflowDropOldest}, 2,
+			[]string{"msg=m0", "msg=m3", "msg=m4"}, []string{"msg=m1", "msg=m2"}},
+		{"drop below", Async{BufferSize: 2, Overflow: OverflowDropBelow, DropBelow: WarnLevel}, 1,
+			[]string{"msg=m0", "msg=m1", "msg=m2", "msg=m4"}, []string{"msg=m3"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := &blockingWriter{started: make(chan struct{}), release: make(chan struct{})}
+			logger := NewTextLogger(w, &slog.HandlerOptions{Level: slog.LevelInfo})
+			logger.SetAsync(&test.async)
+
+			// m0 is picked up by the writer goroutine, which then blocks, m1 and m2 fill the queue
+			logger.Info("m0")
+			<-w.started
+			logger.Info("m1")
+			logger.Warn("m2")
+			logger.Info("m3")
+			if test.async.Overflow == OverflowDropBelow {
+				// blocks until m0 is written, so release the writer in the background
+				go close(w.release)
+				logger.Error("m4")
+			} else {
+				logger.Error("m4")
+				close(w.release)
+			}
+			dropped := logger.AsyncDropped()
+			logger.Close()
+
+			if dropped != test.dropped {
+				t.Errorf("AsyncDropped() = %d, want %d", dropped, test.dropped)
+			}
+
+			output := w.String()
+			for _, want := range test.expected {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected %q in output: %s", want, output)
+				}
+			}
+			for _, missing := range test.missing {
+				if strings.Contains(output, missing) {
+					t.Errorf("Expected %q to be dropped: %s", missing, output)
+				}
+			}
+		})
+	}
+}
+
+func TestAsyncWithDeduplication(t *testing.T) {
+	var buf syncBuffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetAsync(&Async{})
+	logger.SetDeduplication(&Deduplication{})
+
+	for i := 0; i < 3; i++ {
+		logger.Info("repeated")
+	}
+	logger.Close()
+
+	output := buf.String()
+	if !strings.Contains(output, "msg=repeated repeat_count=2") {
+		t.Errorf("Expected repeat summary to be written on Close: %s", output)
+	}
+}
@@ -0,0 +1,369 @@
+package logrus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Config describes a logger, it is meant to be embedded in the configuration file of an
+// application. Decoding it from JSON fails on unknown keys. The zero value describes
+// the defaults of New: text output to stderr at info level.
+type Config struct {
+	// Level is the level as understood by ParseLevel, info when empty
+	Level string `json:"level,omitempty"`
+
+	// Format is "text" or "json", text when empty
+	Format string `json:"format,omitempty"`
+
+	// Output is "stderr", "stdout" or the path of a file to append to, stderr when empty
+	Output string `json:"output,omitempty"`
+
+	// Sinks are additional outputs, see Sink
+	Sinks []SinkConfig `json:"sinks,omitempty"`
+
+	// Fields are added to every entry
+	Fields map[string]any `json:"fields,omitempty"`
+
+	// Caller enables caller reporting
+	Caller bool `json:"caller,omitempty"`
+
+	// Sampling enables sampling when set
+	Sampling *SamplingConfig `json:"sampling,omitempty"`
+
+	// Redaction enables redaction when set
+	Redaction *RedactionConfig `json:"redaction,omitempty"`
+}
+
+// SinkConfig describes an additional output.
+type SinkConfig struct {
+	// Name identifies the sink
+	Name string `json:"name"`
+
+	// Output is "stderr", "stdout" or the path of a file to append to
+	Output string `json:"output"`
+
+	// Format is "text" or "json", text when empty
+	Format string `json:"format,omitempty"`
+
+	// Level is the level of the sink, info when empty
+	Level string `json:"level,omitempty"`
+}
+
+// SamplingConfig describes Sampling.
+type SamplingConfig struct {
+	// Tick is a duration such as "1s", 1 second when empty
+	Tick string `json:"tick,omitempty"`
+
+	First       int    `json:"first"`
+	Thereafter  int    `json:"thereafter"`
+	ExemptLevel string `json:"exempt_level,omitempty"`
+}
+
+// RedactionConfig describes Redaction, Patterns are regular expressions.
+type RedactionConfig struct {
+	Keys        []string `json:"keys,omitempty"`
+	Patterns    []string `json:"patterns,omitempty"`
+	Replacement string   `json:"replacement,omitempty"`
+}
+
+// UnmarshalJSON decodes the configuration and rejects keys that are not known, also when
+// the configuration is part of a larger document decoded without DisallowUnknownFields.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	// config has the fields of Config but not this method
+	type config Config
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var decoded config
+	err := dec.Decode(&decoded)
+	if err != nil {
+		return fmt.Errorf("invalid logging configuration: %w", err)
+	}
+
+	*c = Config(decoded)
+
+	return nil
+}
+
+// resolvedConfig is a validated Config with its outputs opened.
+type resolvedConfig struct {
+	level     Level
+	formatter Formatter
+	out       io.Writer
+	sinks     []Sink
+	fields    Fields
+	caller    bool
+	sampling  *Sampling
+	redaction *Redaction
+	closers   []io.Closer
+}
+
+// NewFromConfig creates a Logger described by config.
+func NewFromConfig(config Config) (*Logger, error) {
+	resolved, err := resolveConfig(config, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{
+		Level:     resolved.level.toSlogLevel(),
+		AddSource: resolved.caller,
+	}
+
+	var logger *Logger
+	if _, ok := resolved.formatter.(*JSONFormatter); ok {
+		logger = NewJSONLogger(resolved.out, opts)
+	} else {
+		logger = NewTextLogger(resolved.out, opts)
+	}
+
+	err = logger.applyConfig(resolved)
+	if err != nil {
+		resolved.close()
+		return nil, err
+	}
+
+	return logger, nil
+}
+
+// ApplyConfig reconfigures the logger as described by config, for example when the
+// configuration file of the application is reloaded. Nothing changes when config is
+// invalid. Sinks of a previously applied configuration are removed and its files closed
+// once entries held by deduplication or asynchronous output are written, sinks added with
+// AddSink are kept.
+func (logger *Logger) ApplyConfig(config Config) error {
+	logger.configMu.Lock()
+	defer logger.configMu.Unlock()
+
+	resolved, err := resolveConfig(config, logger)
+	if err != nil {
+		return err
+	}
+
+	err = logger.applyConfig(resolved)
+	if err != nil {
+		resolved.close()
+		return err
+	}
+
+	return nil
+}
+
+// applyConfig applies resolved to the logger, replacing the previously applied configuration.
+// The output, level, fields and sinks are swapped at once so that concurrent entries are
+// written either with the previous configuration or the new one.
+func (logger *Logger) applyConfig(resolved *resolvedConfig) error {
+	// redaction is applied first as it is the only step that can fail
+	err := logger.SetRedaction(resolved.redaction)
+	if err != nil {
+		return fmt.Errorf("invalid redaction: %w", err)
+	}
+	logger.SetSampling(resolved.sampling)
+
+	logger.handlerMu.Lock()
+
+	logger.mu.Lock()
+	previous := make(map[string]bool, len(logger.configSinks))
+	for _, name := range logger.configSinks {
+		previous[name] = true
+	}
+
+	// names were checked against the existing sinks by resolveConfig
+	sinks := make([]Sink, 0, len(logger.sinks)+len(resolved.sinks))
+	for _, s := range logger.sinks {
+		if !previous[s.Name] {
+			sinks = append(sinks, s)
+		}
+	}
+	logger.configSinks = nil
+	for _, s := range resolved.sinks {
+		sinks = append(sinks, s)
+		logger.configSinks = append(logger.configSinks, s.Name)
+	}
+	logger.sinks = sinks
+
+	logger.defaultFields = resolved.fields
+	previousClosers := logger.configClosers
+	logger.configClosers = resolved.closers
+	logger.mu.Unlock()
+
+	atomic.StoreUint32((*uint32)(&logger.Level), uint32(resolved.level))
+	logger.reportCaller = resolved.caller
+	logger.Formatter = resolved.formatter
+	logger.Out = resolved.out
+	logger.setHandler(newFormatterHandler(resolved.formatter, resolved.out, logger.handlerOptions()))
+
+	logger.handlerMu.Unlock()
+
+	// entries held for the previous outputs are written before they are closed
+	logger.Flush()
+	for _, c := range previousClosers {
+		c.Close()
+	}
+
+	return nil
+}
+
+// resolveConfig validates config and opens its outputs, sink names must not clash with
+// sinks of logger that were not added by a configuration.
+func resolveConfig(config Config, logger *Logger) (_ *resolvedConfig, err error) {
+	resolved := &resolvedConfig{fields: Fields{}, caller: config.Caller}
+	defer func() {
+		if err != nil {
+			resolved.close()
+		}
+	}()
+
+	resolved.level, err = parseConfigLevel("level", config.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved.formatter, err = parseConfigFormat("format", config.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved.out, err = resolved.open("output", config.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range config.Fields {
+		resolved.fields[k] = v
+	}
+
+	names := map[string]bool{DefaultSink: true}
+	if logger != nil {
+		logger.mu.RLock()
+		for _, s := range logger.sinks {
+			names[s.Name] = true
+		}
+		for _, name := range logger.configSinks {
+			delete(names, name)
+		}
+		logger.mu.RUnlock()
+	}
+
+	for i, sc := range config.Sinks {
+		key := fmt.Sprintf("sinks[%d]", i)
+
+		if sc.Name == "" || names[sc.Name] {
+			return nil, fmt.Errorf("invalid logging configuration: %s.name %q is empty or already used", key, sc.Name)
+		}
+		names[sc.Name] = true
+
+		if sc.Output == "" {
+			return nil, fmt.Errorf("invalid logging configuration: %s.output is required", key)
+		}
+
+		sink := Sink{Name: sc.Name}
+		sink.Level, err = parseConfigLevel(key+".level", sc.Level)
+		if err != nil {
+			return nil, err
+		}
+		sink.Formatter, err = parseConfigFormat(key+".format", sc.Format)
+		if err != nil {
+			return nil, err
+		}
+		sink.Out, err = resolved.open(key+".output", sc.Output)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved.sinks = append(resolved.sinks, sink)
+	}
+
+	if sc := config.Sampling; sc != nil {
+		resolved.sampling = &Sampling{First: sc.First, Thereafter: sc.Thereafter}
+
+		if sc.Tick != "" {
+			resolved.sampling.Tick, err = time.ParseDuration(sc.Tick)
+			if err != nil || resolved.sampling.Tick <= 0 {
+				return nil, fmt.Errorf("invalid logging configuration: sampling.tick %q is not a positive duration", sc.Tick)
+			}
+		}
+
+		if sc.ExemptLevel != "" {
+			resolved.sampling.ExemptLevel, err = parseConfigLevel("sampling.exempt_level", sc.ExemptLevel)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if rc := config.Redaction; rc != nil {
+		resolved.redaction = &Redaction{Keys: rc.Keys, Replacement: rc.Replacement}
+
+		for i, pattern := range rc.Patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid logging configuration: redaction.patterns[%d]: %w", i, err)
+			}
+			resolved.redaction.Patterns = append(resolved.redaction.Patterns, re)
+		}
+	}
+
+	return resolved, nil
+}
+
+// open returns the writer for output, files are closed with the resolved configuration.
+func (r *resolvedConfig) open(key string, output string) (io.Writer, error) {
+	switch output {
+	case "", "stderr":
+		return os.Stderr, nil
+	case "stdout":
+		return os.Stdout, nil
+	}
+
+	file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("invalid logging configuration: %s: %w", key, err)
+	}
+	r.closers = append(r.closers, file)
+
+	return file, nil
+}
+
+// close closes the files opened for the configuration.
+func (r *resolvedConfig) close() {
+	for _, c := range r.closers {
+		c.Close()
+	}
+	r.closers = nil
+}
+
+// parseConfigLevel parses the level of key, info when empty.
+func parseConfigLevel(key string, level string) (Level, error) {
+	if level == "" {
+		return InfoLevel, nil
+	}
+
+	parsed, err := ParseLevel(strings.ToLower(level))
+	if err != nil {
+		return InfoLevel, fmt.Errorf("invalid logging configuration: %s: %w", key, err)
+	}
+
+	return parsed, nil
+}
+
+// parseConfigFormat returns the formatter for the format of key, text when empty.
+func parseConfigFormat(key string, format string) (Formatter, error) {
+	switch strings.ToLower(format) {
+	case "", "text":
+		return &TextFormatter{}, nil
+	case "json":
+		return &JSONFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("invalid logging configuration: %s %q is not text or json", key, format)
+	}
+}
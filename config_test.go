@@ -0,0 +1,208 @@
+package logrus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigUnmarshalUnknownKey(t *testing.T) {
+	var doc struct {
+		Logging Config `json:"logging"`
+	}
+
+	err := json.Unmarshal([]byte(`{"logging": {"level": "debug", "levle": "info"}}`), &doc)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "levle"`) {
+		t.Fatalf("Unmarshal() error = %v, want unknown field error", err)
+	}
+
+	err = json.Unmarshal([]byte(`{"logging": {"sampling": {"first": 1, "then": 2}}}`), &doc)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "then"`) {
+		t.Fatalf("Unmarshal() error = %v, want unknown field error", err)
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	errPath := filepath.Join(dir, "errors.log")
+
+	var config Config
+	err := json.Unmarshal([]byte(`{
+		"level": "debug",
+		"format": "json",
+		"output": "`+path+`",
+		"caller": true,
+		"fields": {"service": "api"},
+		"sinks": [{"name": "errors", "output": "`+errPath+`", "level": "error"}],
+		"redaction": {"keys": ["password"]}
+	}`), &config)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	logger, err := NewFromConfig(config)
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	defer logger.ApplyConfig(Config{})
+
+	logger.WithField("password", "secret").Debug("debug")
+	logger.Error("failed")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2:\n%s", len(lines), data)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("line is not JSON: %q", lines[0])
+	}
+	if entry["service"] != "api" || entry["password"] == "secret" {
+		t.Errorf("fields not configured: %v", entry)
+	}
+	if _, ok := entry["source"]; !ok {
+		t.Errorf("caller missing: %v", entry)
+	}
+
+	data, err = os.ReadFile(errPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(data), "debug") || !strings.Contains(string(data), "failed") {
+		t.Errorf("sink output = %q", data)
+	}
+}
+
+func TestNewFromConfigInvalid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		config Config
+		want   string
+	}{
+		"level":   {Config{Level: "loud"}, "level"},
+		"format":  {Config{Format: "xml"}, "format"},
+		"sink":    {Config{Sinks: []SinkConfig{{Name: "a", Output: "stdout", Level: "loud"}}}, "sinks[0].level"},
+		"default": {Config{Sinks: []SinkConfig{{Name: DefaultSink, Output: "stdout"}}}, "sinks[0].name"},
+		"output":  {Config{Sinks: []SinkConfig{{Name: "a"}}}, "sinks[0].output"},
+		"tick":    {Config{Sampling: &SamplingConfig{Tick: "soon"}}, "sampling.tick"},
+		"pattern": {Config{Redaction: &RedactionConfig{Patterns: []string{"("}}}, "redaction.patterns[0]"},
+		"file":    {Config{Output: filepath.Join(t.TempDir(), "missing", "app.log")}, "output"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewFromConfig(tc.config)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("NewFromConfig() error = %v, want mention of %s", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplyConfig(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+
+	logger, err := NewFromConfig(Config{Output: first, Sinks: []SinkConfig{{Name: "copy", Output: "stdout"}}})
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	logger.AddSink(Sink{Name: "manual", Out: &syncBuffer{}})
+	out := logger.Out.(*os.File)
+
+	// an invalid configuration changes nothing
+	err = logger.ApplyConfig(Config{Level: "debug", Output: second, Format: "yaml"})
+	if err == nil {
+		t.Fatal("ApplyConfig() error = nil, want an error")
+	}
+	if logger.GetLevel() != InfoLevel || logger.Out != out {
+		t.Fatal("invalid configuration was applied")
+	}
+	if _, err := os.Stat(second); err == nil {
+		t.Error("invalid configuration left its output behind open")
+	}
+
+	err = logger.ApplyConfig(Config{Level: "debug", Output: second, Sinks: []SinkConfig{{Name: "copy", Output: "stderr"}}})
+	if err != nil {
+		t.Fatalf("ApplyConfig() error = %v", err)
+	}
+	defer logger.ApplyConfig(Config{})
+
+	if logger.GetLevel() != DebugLevel {
+		t.Errorf("level = %v, want %v", logger.GetLevel(), DebugLevel)
+	}
+	if _, err := out.Write([]byte("x")); err == nil {
+		t.Error("output of the previous configuration was not closed")
+	}
+
+	logger.mu.RLock()
+	var names []string
+	for _, s := range logger.sinks {
+		names = append(names, s.Name)
+	}
+	logger.mu.RUnlock()
+	if strings.Join(names, ",") != "manual,copy" {
+		t.Errorf("sinks = %v, want [manual copy]", names)
+	}
+
+	// sinks added with AddSink cannot be taken over by a configuration
+	err = logger.ApplyConfig(Config{Sinks: []SinkConfig{{Name: "manual", Output: "stdout"}}})
+	if err == nil {
+		t.Error("ApplyConfig() error = nil, want a sink name error")
+	}
+}
+
+func TestApplyConfigConcurrentLogging(t *testing.T) {
+	dir := t.TempDir()
+
+	logger, err := NewFromConfig(Config{Output: filepath.Join(dir, "0.log")})
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	defer logger.ApplyConfig(Config{})
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				logger.WithError(errors.New("boom")).Warn("while reloading")
+			}
+		}
+	}()
+
+	for i := 1; i <= 10; i++ {
+		format := "text"
+		if i%2 == 0 {
+			format = "json"
+		}
+
+		err := logger.ApplyConfig(Config{Format: format, Output: filepath.Join(dir, fmt.Sprintf("%d.log", i))})
+		if err != nil {
+			t.Fatalf("ApplyConfig() error = %v", err)
+		}
+	}
+	close(stop)
+	<-done
+
+	logger.Warn("last entry")
+	data, err := os.ReadFile(filepath.Join(dir, "10.log"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"msg":"last entry"`) {
+		t.Errorf("entry was not written to the last output:\n%s", data)
+	}
+}
@@ -0,0 +1,81 @@
+package logrus
+
+import (
+	"context"
+)
+
+// entryContextKey is the context key under which NewContext stores an Entry.
+type entryContextKey struct{}
+
+// NewContext returns a copy of ctx that carries entry, retrieve it with FromContext.
+func NewContext(ctx context.Context, entry *Entry) context.Context {
+	if ctx == nil {
+		ctx = backgroundContext
+	}
+	return context.WithValue(ctx, entryContextKey{}, entry)
+}
+
+// FromContext returns the Entry stored in ctx by NewContext, or a new Entry for the
+// standard logger when there is none. The returned entry carries ctx as its context
+// so that context extractors see the values of the context it was taken from.
+func FromContext(ctx context.Context) *Entry {
+	if ctx == nil {
+		return NewEntry(standardLogger)
+	}
+
+	entry, ok := ctx.Value(entryContextKey{}).(*Entry)
+	if !ok || entry == nil {
+		entry = NewEntry(standardLogger)
+	}
+
+	return entry.WithContext(ctx)
+}
+
+// ContextExtractor extracts fields such as request or trace IDs from a context.
+// It is called at log time for every record that carries a context.
+type ContextExtractor func(ctx context.Context) Fields
+
+// AddContextExtractor registers an extractor that is run for entries created with
+// WithContext and for calls made through the slog.Logger returned by GetSlogLogger.
+// Fields set explicitly on an entry take precedence over extracted ones.
+func (logger *Logger) AddContextExtractor(extractor ContextExtractor) {
+	if extractor == nil {
+		return
+	}
+
+	logger.mu.Lock()
+	logger.extractors = append(logger.extractors, extractor)
+	logger.mu.Unlock()
+}
+
+// AddContextExtractor registers a context extractor on the standard logger.
+func AddContextExtractor(extractor ContextExtractor) {
+	standardLogger.AddContextExtractor(extractor)
+}
+
+// extractContextFields runs all registered extractors against ctx and merges their results.
+func (logger *Logger) extractContextFields(ctx context.Context) Fields {
+	logger.mu.RLock()
+	extractors := logger.extractors
+	logger.mu.RUnlock()
+
+	if len(extractors) == 0 {
+		return nil
+	}
+
+	var fields Fields
+	for _, extractor := range extractors {
+		extracted := extractor(ctx)
+		if len(extracted) == 0 {
+			continue
+		}
+		if fields == nil {
+			fields = make(Fields, len(extracted))
+		}
+		for k, v := range extracted {
+			fields[k] = v
+		}
+	}
+
+	return fields
+}
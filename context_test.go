@@ -0,0 +1,113 @@
+package logrus
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type contextTestKey string
+
+func requestIDExtractor(ctx context.Context) Fields {
+	id, ok := ctx.Value(contextTestKey("request_id")).(string)
+	if !ok {
+		return nil
+	}
+	return Fields{"request_id": id}
+}
+
+func TestContextExtractorEntry(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+	logger.AddContextExtractor(requestIDExtractor)
+
+	ctx := context.WithValue(context.Background(), contextTestKey("request_id"), "req-123")
+	logger.WithContext(ctx).WithField("component", "test").Info("with context")
+
+	output := buf.String()
+	if !strings.Contains(output, "request_id=req-123") {
+		t.Errorf("Expected extracted field in output: %s", output)
+	}
+	if !strings.Contains(output, "component=test") {
+		t.Errorf("Expected entry field in output: %s", output)
+	}
+
+	buf.Reset()
+	logger.Info("without context")
+	if strings.Contains(buf.String(), "request_id") {
+		t.Errorf("Did not expect extracted field without a context: %s", buf.String())
+	}
+}
+
+func TestContextExtractorSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf, nil)
+	logger.AddContextExtractor(requestIDExtractor)
+
+	ctx := context.WithValue(context.Background(), contextTestKey("request_id"), "req-456")
+	logger.GetSlogLogger().InfoContext(ctx, "slog message")
+
+	if !strings.Contains(buf.String(), `"request_id":"req-456"`) {
+		t.Errorf("Expected extracted field in slog output: %s", buf.String())
+	}
+}
+
+func TestContextExtractorPrecedence(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.AddContextExtractor(requestIDExtractor)
+	logger.AddContextExtractor(func(ctx context.Context) Fields {
+		return Fields{"tenant": "acme"}
+	})
+
+	ctx := context.WithValue(context.Background(), contextTestKey("request_id"), "from-ctx")
+	logger.WithContext(ctx).WithField("request_id", "explicit").Info("precedence")
+
+	output := buf.String()
+	if strings.Count(output, "request_id=") != 1 || !strings.Contains(output, "request_id=explicit") {
+		t.Errorf("Expected explicit field to win over extracted one: %s", output)
+	}
+	if !strings.Contains(output, "tenant=acme") {
+		t.Errorf("Expected field from second extractor: %s", output)
+	}
+}
+
+func TestNewContextFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+	logger.AddContextExtractor(requestIDExtractor)
+
+	entry := logger.WithField("component", "test")
+	ctx := NewContext(context.Background(), entry)
+	ctx = context.WithValue(ctx, contextTestKey("request_id"), "req-789")
+
+	found := FromContext(ctx)
+	if found.Logger != logger {
+		t.Error("FromContext() did not return an entry for the stored logger")
+	}
+	if found.Context != ctx {
+		t.Error("FromContext() did not set the context on the returned entry")
+	}
+
+	found.Info("from context")
+
+	output := buf.String()
+	if !strings.Contains(output, "component=test") {
+		t.Errorf("Expected stored entry field in output: %s", output)
+	}
+	if !strings.Contains(output, "request_id=req-789") {
+		t.Errorf("Expected extracted field in output: %s", output)
+	}
+}
+
+func TestFromContextFallback(t *testing.T) {
+	entry := FromContext(context.Background())
+	if entry == nil {
+		t.Fatal("FromContext() returned nil")
+	}
+	if entry.Logger != StandardLogger() {
+		t.Error("FromContext() without an entry should use the standard logger")
+	}
+}
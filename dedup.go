@@ -0,0 +1,212 @@
+package logrus
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Deduplication configures the collapsing of identical entries, those with the same
+// level, message and fields. The first entry is logged, repeats are held and later
+// summarized in a single entry with repeat_count, first_seen and last_seen fields.
+type Deduplication struct {
+	// Window is how long after an entry was logged identical ones are held, the summary
+	// is logged when the window closes. When 0 only back-to-back repeats are collapsed
+	// and the summary is logged when a different entry is logged or on Flush.
+	Window time.Duration
+}
+
+// minDedupTick is the shortest interval at which closed windows are checked.
+const minDedupTick = time.Millisecond
+
+// dedupKey identifies identical records, the handler is part of the key as attributes
+// added with WithAttrs are not part of the record.
+type dedupKey struct {
+	handler     *pipelineHandler
+	fingerprint string
+}
+
+// repeat tracks a logged record and the repeats held since.
+type repeat struct {
+	ctx       context.Context
+	record    slog.Record
+	handler   *pipelineHandler
+	firstSeen time.Time
+	lastSeen  time.Time
+	count     int
+}
+
+// deduper holds repeated records for a Logger.
+type deduper struct {
+	mu     sync.Mutex
+	window time.Duration
+	seen   map[dedupKey]*repeat
+	last   dedupKey
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// SetDeduplication enables collapsing of identical entries, passing nil disables it.
+// Held repeats of a previous configuration are summarized before the change.
+func (logger *Logger) SetDeduplication(dedup *Deduplication) {
+	var d *deduper
+	if dedup != nil {
+		d = &deduper{
+			window: dedup.Window,
+			seen:   make(map[dedupKey]*repeat),
+		}
+		if d.window > 0 {
+			d.stop = make(chan struct{})
+			d.done = make(chan struct{})
+			go d.expire()
+		}
+	}
+
+	if previous := logger.deduper.Swap(d); previous != nil {
+		previous.close()
+	}
+}
+
+// SetDeduplication enables collapsing of identical entries on the standard logger.
+func SetDeduplication(dedup *Deduplication) {
+	standardLogger.SetDeduplication(dedup)
+}
+
+// handle emits r through h unless it repeats a held record.
+func (d *deduper) handle(ctx context.Context, h *pipelineHandler, r slog.Record) error {
+	key := dedupKey{handler: h, fingerprint: recordFingerprint(r)}
+
+	seen := r.Time
+	if seen.IsZero() {
+		seen = time.Now()
+	}
+
+	d.mu.Lock()
+	if rep, ok := d.seen[key]; ok && (d.window == 0 || seen.Sub(rep.firstSeen) < d.window) {
+		rep.count++
+		rep.lastSeen = seen
+		d.mu.Unlock()
+		return nil
+	}
+
+	var summaries []*repeat
+	if d.window == 0 {
+		// only the last record is held in back-to-back mode
+		if rep, ok := d.seen[d.last]; ok {
+			summaries = append(summaries, rep)
+			delete(d.seen, d.last)
+		}
+	} else if rep, ok := d.seen[key]; ok {
+		summaries = append(summaries, rep)
+	}
+
+	d.seen[key] = &repeat{ctx: ctx, record: r.Clone(), handler: h, firstSeen: seen, lastSeen: seen}
+	d.last = key
+	d.mu.Unlock()
+
+	emitSummaries(summaries)
+
+	return h.emit(ctx, r)
+}
+
+// flush logs the summaries of all held repeats and forgets all records.
+func (d *deduper) flush() {
+	d.mu.Lock()
+	summaries := make([]*repeat, 0, len(d.seen))
+	for key, rep := range d.seen {
+		summaries = append(summaries, rep)
+		delete(d.seen, key)
+	}
+	d.mu.Unlock()
+
+	emitSummaries(summaries)
+}
+
+// close stops the expiry of windows and flushes all held repeats.
+func (d *deduper) close() {
+	if d.stop != nil {
+		close(d.stop)
+		<-d.done
+	}
+	d.flush()
+}
+
+// expire logs the summaries of windows that have closed.
+func (d *deduper) expire() {
+	defer close(d.done)
+
+	tick := d.window / 2
+	if tick < minDedupTick {
+		tick = minDedupTick
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case now := <-ticker.C:
+			var summaries []*repeat
+
+			d.mu.Lock()
+			for key, rep := range d.seen {
+				if now.Sub(rep.firstSeen) >= d.window {
+					summaries = append(summaries, rep)
+					delete(d.seen, key)
+				}
+			}
+			d.mu.Unlock()
+
+			emitSummaries(summaries)
+		}
+	}
+}
+
+// emitSummaries logs a summary for every repeat that held records.
+func emitSummaries(repeats []*repeat) {
+	for _, rep := range repeats {
+		if rep.count == 0 {
+			continue
+		}
+
+		r := slog.NewRecord(time.Now(), rep.record.Level, rep.record.Message, rep.record.PC)
+		rep.record.Attrs(func(a slog.Attr) bool {
+			r.AddAttrs(a)
+			return true
+		})
+		r.AddAttrs(
+			slog.Int("repeat_count", rep.count),
+			slog.Time("first_seen", rep.firstSeen),
+			slog.Time("last_seen", rep.lastSeen),
+		)
+
+		rep.handler.emit(rep.ctx, r)
+	}
+}
+
+// recordFingerprint identifies records with the same level, message and attributes.
+func recordFingerprint(r slog.Record) string {
+	// fields of entries are added in map order, so attributes are sorted
+	attrs := make([]string, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a.String())
+		return true
+	})
+	sort.Strings(attrs)
+
+	var b strings.Builder
+	b.WriteString(r.Level.String())
+	b.WriteByte(0)
+	b.WriteString(r.Message)
+	for _, a := range attrs {
+		b.WriteByte(0)
+		b.WriteString(a)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package logrus
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeduplicationBackToBack(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetDeduplication(&Deduplication{})
+
+	for i := 0; i < 5; i++ {
+		logger.WithFields(Fields{"a": 1, "b": 2, "c": 3}).Warn("disk full")
+	}
+	logger.WithField("a", 2).Warn("disk full")
+	logger.Info("different")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 lines, got %d: %s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `msg="disk full"`) || strings.Contains(lines[0], "repeat_count") {
+		t.Errorf("Expected first entry to be logged as is: %s", lines[0])
+	}
+	for _, want := range []string{`msg="disk full"`, "a=1", "b=2", "c=3", "repeat_count=4", "first_seen=", "last_seen="} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("Expected %q in repeat summary: %s", want, lines[1])
+		}
+	}
+	if !strings.Contains(lines[2], "a=2") || !strings.Contains(lines[3], "different") {
+		t.Errorf("Expected entries with different fields and messages: %s", buf.String())
+	}
+}
+
+func TestDeduplicationFlush(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetDeduplication(&Deduplication{})
+
+	slogger := logger.GetSlogLogger()
+	slogger.Info("from slog", "k", "v")
+	slogger.Info("from slog", "k", "v")
+
+	if strings.Contains(buf.String(), "repeat_count") {
+		t.Errorf("Did not expect a summary before Flush: %s", buf.String())
+	}
+
+	logger.Flush()
+	if !strings.Contains(buf.String(), "k=v repeat_count=1") {
+		t.Errorf("Expected summary after Flush: %s", buf.String())
+	}
+}
+
+func TestDeduplicationWindow(t *testing.T) {
+	var buf syncBuffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetDeduplication(&Deduplication{Window: 100 * time.Millisecond})
+	defer logger.SetDeduplication(nil)
+
+	logger.Error("connection refused")
+	logger.Info("interleaved")
+	logger.Error("connection refused")
+	logger.Error("connection refused")
+
+	output := buf.String()
+	if n := strings.Count(output, "connection refused"); n != 1 {
+		t.Errorf("Expected repeats within the window to be held, got %d: %s", n, output)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+
+	output = buf.String()
+	if !strings.Contains(output, `msg="connection refused" repeat_count=2`) {
+		t.Errorf("Expected summary after the window closed: %s", output)
+	}
+
+	logger.Error("connection refused")
+	if n := strings.Count(buf.String(), "connection refused"); n != 3 {
+		t.Errorf("Expected a new window to log the entry again, got %d", n)
+	}
+}
+
+func TestDeduplicationTinyWindow(t *testing.T) {
+	var buf syncBuffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetDeduplication(&Deduplication{Window: time.Nanosecond})
+	defer logger.SetDeduplication(nil)
+
+	logger.Error("connection refused")
+	time.Sleep(10 * time.Millisecond)
+	logger.Error("connection refused")
+
+	if n := strings.Count(buf.String(), "connection refused"); n != 2 {
+		t.Errorf("Expected entries after the window to be logged, got %d: %s", n, buf.String())
+	}
+}
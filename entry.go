@@ -1,7 +1,6 @@
 package logrus
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"io"
@@ -17,6 +16,7 @@ var backgroundContext = context.Background()
 // Entry represents a single log entry, compatible with logrus.Entry.
 type Entry struct {
 	logger *Logger
+	name   string
 	Data   Fields
 	Time   time.Time
 	Level  Level
@@ -56,6 +56,7 @@ func (entry *Entry) WithField(key string, value any) *Entry {
 	data[key] = value
 	return &Entry{
 		logger:  entry.logger,
+		name:    entry.name,
 		Data:    data,
 		Time:    entry.Time,
 		Level:   entry.Level,
@@ -76,6 +77,7 @@ func (entry *Entry) WithFields(fields Fields) *Entry {
 	}
 	return &Entry{
 		logger:  entry.logger,
+		name:    entry.name,
 		Data:    data,
 		Time:    entry.Time,
 		Level:   entry.Level,
@@ -93,17 +95,19 @@ func (entry *Entry) WithContext(ctx context.Context) *Entry {
 	}
 	return &Entry{
 		logger:  entry.logger,
+		name:    entry.name,
 		Data:    dataCopy,
 		Time:    entry.Time,
 		Level:   entry.Level,
 		Caller:  entry.Caller,
 		Context: ctx,
+		Logger:  entry.logger,
 	}
 }
 
 // WithError adds an error field to the Entry.
 func (entry *Entry) WithError(err error) *Entry {
-	return entry.WithField("error", err)
+	return entry.WithField(ErrorKey, err)
 }
 
 // WithTime adds a time field to the Entry.
@@ -114,75 +118,99 @@ func (entry *Entry) WithTime(t time.Time) *Entry {
 	}
 	return &Entry{
 		logger:  entry.logger,
+		name:    entry.name,
 		Data:    dataCopy,
 		Time:    t,
 		Level:   entry.Level,
 		Caller:  entry.Caller,
 		Context: entry.Context,
+		Logger:  entry.logger,
+	}
+}
+
+// write sends msg and the entry's fields to slog at the given level, ctx is the
+// context returned by levelContext.
+func (entry *Entry) write(ctx context.Context, level Level, msg string) {
+	if len(entry.Data) == 0 {
+		// Fast path - no attributes
+		entry.logger.slogger.Load().Log(ctx, level.toSlogLevel(), msg)
+	} else {
+		// Slow path - with attributes
+		entry.logger.slogger.Load().LogAttrs(ctx, level.toSlogLevel(), msg, entry.attrs()...)
 	}
 }
 
+// writeRecord sends msg and the entry's fields to slog as a record with the entry's
+// time and the caller identified by pc, it is used where the caller is known up front.
+func (entry *Entry) writeRecord(level slog.Level, pc uintptr, msg string) error {
+	handler := entry.logger.slogger.Load().Handler()
+	if !handler.Enabled(entry.Context, level) {
+		return nil
+	}
+
+	r := slog.NewRecord(entry.Time, level, msg, pc)
+	r.AddAttrs(entry.attrs()...)
+
+	return handler.Handle(entry.Context, r)
+}
+
+// attrs converts the entry's fields into slog attributes.
+func (entry *Entry) attrs() []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(entry.Data))
+	for k, v := range entry.Data {
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	return attrs
+}
+
 // log is the internal logging method that writes to slog
 func (entry *Entry) log(level Level, args ...any) {
-	if !entry.logger.IsLevelEnabled(level) {
+	ctx, ok := entry.levelContext(level)
+	if !ok || !entry.logger.sampleArgs(level, args) {
 		return
 	}
 
 	// Get message
 	msg := fmt.Sprint(args...)
 
-	if len(entry.Data) == 0 {
-		// Fast path - no attributes
-		entry.logger.slogger.Log(entry.Context, level.toSlogLevel(), msg)
-	} else {
-		// Slow path - with attributes
-		attrs := make([]slog.Attr, 0, len(entry.Data))
-		for k, v := range entry.Data {
-			attrs = append(attrs, slog.Any(k, v))
-		}
-		entry.logger.slogger.LogAttrs(entry.Context, level.toSlogLevel(), msg, attrs...)
-	}
+	entry.write(ctx, level, msg)
 
 	// Handle Fatal and Panic levels
 	if level == FatalLevel {
+		entry.logger.Flush()
 		os.Exit(1)
 	} else if level == PanicLevel {
+		entry.logger.Flush()
 		panic(msg)
 	}
 }
 
 // logf is the internal formatted logging method
 func (entry *Entry) logf(level Level, format string, args ...any) {
-	if !entry.logger.IsLevelEnabled(level) {
+	ctx, ok := entry.levelContext(level)
+	if !ok || !entry.logger.sample(level, format) {
 		return
 	}
 
 	// Format message
 	msg := fmt.Sprintf(format, args...)
 
-	if len(entry.Data) == 0 {
-		// Fast path - no attributes
-		entry.logger.slogger.Log(entry.Context, level.toSlogLevel(), msg)
-	} else {
-		// Slow path - with attributes
-		attrs := make([]slog.Attr, 0, len(entry.Data))
-		for k, v := range entry.Data {
-			attrs = append(attrs, slog.Any(k, v))
-		}
-		entry.logger.slogger.LogAttrs(entry.Context, level.toSlogLevel(), msg, attrs...)
-	}
+	entry.write(ctx, level, msg)
 
 	// Handle Fatal and Panic levels
 	if level == FatalLevel {
+		entry.logger.Flush()
 		os.Exit(1)
 	} else if level == PanicLevel {
+		entry.logger.Flush()
 		panic(msg)
 	}
 }
 
 // logln is the internal line logging method
 func (entry *Entry) logln(level Level, args ...any) {
-	if !entry.logger.IsLevelEnabled(level) {
+	ctx, ok := entry.levelContext(level)
+	if !ok || !entry.logger.sampleArgs(level, args) {
 		return
 	}
 
@@ -193,22 +221,14 @@ func (entry *Entry) logln(level Level, args ...any) {
 		msg = msg[:len(msg)-1]
 	}
 
-	if len(entry.Data) == 0 {
-		// Fast path - no attributes
-		entry.logger.slogger.Log(entry.Context, level.toSlogLevel(), msg)
-	} else {
-		// Slow path - with attributes
-		attrs := make([]slog.Attr, 0, len(entry.Data))
-		for k, v := range entry.Data {
-			attrs = append(attrs, slog.Any(k, v))
-		}
-		entry.logger.slogger.LogAttrs(entry.Context, level.toSlogLevel(), msg, attrs...)
-	}
+	entry.write(ctx, level, msg)
 
 	// Handle Fatal and Panic levels
 	if level == FatalLevel {
+		entry.logger.Flush()
 		os.Exit(1)
 	} else if level == PanicLevel {
+		entry.logger.Flush()
 		panic(msg)
 	}
 }
@@ -359,51 +379,60 @@ func (entry *Entry) Writer() *io.PipeWriter {
 
 // WriterLevel returns an io.Writer that writes to the logger at the given log Level.
 func (entry *Entry) WriterLevel(level Level) *io.PipeWriter {
+	printFunc := entry.printFunc(level)
+	return entry.pipeWriter(func(line string) { printFunc(line) })
+}
+
+// pipeWriter returns a pipe whose lines are passed to print by a goroutine.
+func (entry *Entry) pipeWriter(print func(line string)) *io.PipeWriter {
 	reader, writer := io.Pipe()
 
-	var printFunc func(args ...any)
+	go entry.writerScanner(reader, print)
+
+	runtime.SetFinalizer(writer, writerFinalizer)
+
+	return writer
+}
 
+// printFunc returns the logging method of the entry for the given level.
+func (entry *Entry) printFunc(level Level) func(args ...any) {
 	switch level {
 	case TraceLevel:
-		printFunc = entry.Trace
+		return entry.Trace
 	case DebugLevel:
-		printFunc = entry.Debug
+		return entry.Debug
 	case InfoLevel:
-		printFunc = entry.Info
+		return entry.Info
 	case WarnLevel:
-		printFunc = entry.Warn
+		return entry.Warn
 	case ErrorLevel:
-		printFunc = entry.Error
+		return entry.Error
 	case FatalLevel:
-		printFunc = entry.Fatal
+		return entry.Fatal
 	case PanicLevel:
-		printFunc = entry.Panic
+		return entry.Panic
 	default:
-		printFunc = entry.Print
+		return entry.Print
 	}
-
-	go entry.writerScanner(reader, printFunc)
-
-	runtime.SetFinalizer(writer, writerFinalizer)
-
-	return writer
 }
 
 // writerScanner scans the input from the reader and writes it to the logger.
-func (entry *Entry) writerScanner(reader *io.PipeReader, printFunc func(args ...any)) {
-	scanner := bufio.NewScanner(reader)
-
-	// Use a reasonable buffer size for scanning
-	scanner.Buffer(make([]byte, bufio.MaxScanTokenSize), bufio.MaxScanTokenSize)
-
-	for scanner.Scan() {
-		printFunc(scanner.Text())
-	}
-
-	if err := scanner.Err(); err != nil {
-		entry.Error("Error while reading from Writer: ", err)
+func (entry *Entry) writerScanner(reader *io.PipeReader, print func(line string)) {
+	lines := entry.logger.newLineBuffer(print)
+	buf := make([]byte, 32*1024)
+
+	for {
+		n, err := reader.Read(buf)
+		lines.write(buf[:n])
+		if err != nil {
+			if err != io.EOF {
+				entry.Error("Error while reading from Writer: ", err)
+			}
+			break
+		}
 	}
 
+	lines.flush()
 	reader.Close()
 }
 
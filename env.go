@@ -0,0 +1,137 @@
+package logrus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Environment variables read by ConfigureFromEnv.
+const (
+	// EnvLevel sets the level, for example "debug"
+	EnvLevel = "SLOGRUS_LEVEL"
+
+	// EnvFormat selects "text" or "json" output
+	EnvFormat = "SLOGRUS_FORMAT"
+
+	// EnvOutput is "stderr", "stdout" or the path of a file to append to
+	EnvOutput = "SLOGRUS_OUTPUT"
+
+	// EnvCaller enables caller reporting when true, for example "1"
+	EnvCaller = "SLOGRUS_CALLER"
+
+	// EnvFields sets default fields, for example "service=api,region=eu"
+	EnvFields = "SLOGRUS_FIELDS"
+)
+
+func init() {
+	err := standardLogger.ConfigureFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "slogrus: %v\n", err)
+	}
+}
+
+// ConfigureFromEnv configures the logger from the SLOGRUS_ environment variables, unset
+// variables leave the configuration unchanged. The standard logger is configured this
+// way when the package is initialized, reporting invalid values on stderr. All invalid
+// values are reported in the returned error while valid ones are still applied.
+func (logger *Logger) ConfigureFromEnv() error {
+	var errs []error
+
+	if format, ok := os.LookupEnv(EnvFormat); ok {
+		switch strings.ToLower(strings.TrimSpace(format)) {
+		case "text":
+			logger.SetFormatter(&TextFormatter{})
+		case "json":
+			logger.SetFormatter(&JSONFormatter{})
+		default:
+			errs = append(errs, &ParseError{msg: fmt.Sprintf("not a valid %s: %q", EnvFormat, format)})
+		}
+	}
+
+	if output, ok := os.LookupEnv(EnvOutput); ok {
+		out, err := envOutput(output)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			logger.SetOutput(out)
+		}
+	}
+
+	if fields, ok := os.LookupEnv(EnvFields); ok {
+		parsed, err := parseEnvFields(fields)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			logger.SetDefaultFields(parsed)
+		}
+	}
+
+	if level, ok := os.LookupEnv(EnvLevel); ok {
+		parsed, err := ParseLevel(strings.ToLower(strings.TrimSpace(level)))
+		if err != nil {
+			errs = append(errs, &ParseError{msg: fmt.Sprintf("not a valid %s: %q", EnvLevel, level)})
+		} else {
+			logger.SetLevel(parsed)
+		}
+	}
+
+	if caller, ok := os.LookupEnv(EnvCaller); ok {
+		enabled, err := strconv.ParseBool(strings.TrimSpace(caller))
+		if err != nil {
+			errs = append(errs, &ParseError{msg: fmt.Sprintf("not a valid %s: %q", EnvCaller, caller)})
+		} else {
+			logger.SetReportCaller(enabled)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ConfigureFromEnv configures the standard logger from the SLOGRUS_ environment variables.
+func ConfigureFromEnv() error {
+	return standardLogger.ConfigureFromEnv()
+}
+
+// envOutput returns the writer named by the value of EnvOutput.
+func envOutput(output string) (io.Writer, error) {
+	switch strings.TrimSpace(output) {
+	case "stderr":
+		return os.Stderr, nil
+	case "stdout":
+		return os.Stdout, nil
+	case "":
+		return nil, &ParseError{msg: fmt.Sprintf("not a valid %s: %q", EnvOutput, output)}
+	}
+
+	file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s: %w", EnvOutput, err)
+	}
+
+	return file, nil
+}
+
+// parseEnvFields parses the comma separated key=value pairs of EnvFields.
+func parseEnvFields(value string) (Fields, error) {
+	fields := Fields{}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, &ParseError{msg: fmt.Sprintf("not a valid %s entry: %q", EnvFields, pair)}
+		}
+		fields[k] = strings.TrimSpace(v)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,83 @@
+package logrus
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigureFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	t.Setenv(EnvLevel, "debug")
+	t.Setenv(EnvFormat, "json")
+	t.Setenv(EnvOutput, path)
+	t.Setenv(EnvCaller, "1")
+	t.Setenv(EnvFields, "service=api, region=eu")
+
+	logger := New()
+	if err := logger.ConfigureFromEnv(); err != nil {
+		t.Fatalf("ConfigureFromEnv() error = %v", err)
+	}
+	defer logger.Out.(*os.File).Close()
+
+	logger.WithField("k", "v").Debug("configured")
+	logger.GetSlogLogger().Info("through slog")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2:\n%s", len(lines), data)
+	}
+	for _, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line is not JSON: %q", line)
+		}
+		if entry["service"] != "api" || entry["region"] != "eu" {
+			t.Errorf("default fields missing: %v", entry)
+		}
+		if _, ok := entry["source"]; !ok {
+			t.Errorf("caller missing: %v", entry)
+		}
+	}
+}
+
+func TestConfigureFromEnvInvalid(t *testing.T) {
+	t.Setenv(EnvLevel, "loud")
+	t.Setenv(EnvFormat, "xml")
+	t.Setenv(EnvCaller, "maybe")
+	t.Setenv(EnvFields, "service")
+	t.Setenv(EnvOutput, "stdout")
+
+	logger := New()
+	err := logger.ConfigureFromEnv()
+	if err == nil {
+		t.Fatal("ConfigureFromEnv() error = nil, want an error")
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("error %v is not a ParseError", err)
+	}
+	for _, name := range []string{EnvLevel, EnvFormat, EnvCaller, EnvFields} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q does not mention %s", err, name)
+		}
+	}
+
+	// valid values are still applied, invalid ones leave the configuration unchanged
+	if logger.Out != os.Stdout {
+		t.Error("valid SLOGRUS_OUTPUT was not applied")
+	}
+	if logger.GetLevel() != InfoLevel {
+		t.Errorf("level = %v, want %v", logger.GetLevel(), InfoLevel)
+	}
+}
@@ -0,0 +1,156 @@
+package logrus
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// ErrorKey is the field name used by WithError, it matches logrus.ErrorKey.
+var ErrorKey = "error"
+
+// appendErrorAttrs appends the attributes describing err stored under key. Errors that wrap
+// or join other errors get a chain of their messages and errors carrying a stack trace,
+// such as those from github.com/pkg/errors, get their stack. Text output uses the keys
+// key, key.chain and key.stack, with entries separated by "; ", while JSON output always
+// groups them under key, also for plain errors, so that the type of key does not depend
+// on the error.
+func (c attrConverter) appendErrorAttrs(attrs []slog.Attr, key string, err error) []slog.Attr {
+	// fmt prints nil pointers as <nil> and recovers from panics in Error methods
+	msg := fmt.Sprint(err)
+
+	var chain, stack []string
+	if !isNilError(err) {
+		chain = errorChain(err)
+		stack = errorStack(err)
+	}
+
+	if c.redactor != nil {
+		msg = c.redactor.redactString(msg)
+		for i := range chain {
+			chain[i] = c.redactor.redactString(chain[i])
+		}
+	}
+
+	if c.json {
+		group := make([]slog.Attr, 0, 3)
+		group = append(group, slog.String("msg", msg))
+		if len(chain) > 0 {
+			group = append(group, slog.Any("chain", chain))
+		}
+		if len(stack) > 0 {
+			group = append(group, slog.Any("stack", stack))
+		}
+		return append(attrs, slog.Attr{Key: key, Value: slog.GroupValue(group...)})
+	}
+
+	attrs = append(attrs, slog.String(key, msg))
+	if len(chain) > 0 {
+		attrs = append(attrs, slog.String(key+".chain", strings.Join(chain, "; ")))
+	}
+	if len(stack) > 0 {
+		attrs = append(attrs, slog.String(key+".stack", strings.Join(stack, "; ")))
+	}
+
+	return attrs
+}
+
+// errorChain returns the messages of all errors wrapped by err, depth first,
+// following both Unwrap() error and the Unwrap() []error of errors.Join.
+func errorChain(err error) []string {
+	var chain []string
+
+	var walk func(error)
+	walk = func(err error) {
+		if isNilError(err) {
+			return
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, member := range e.Unwrap() {
+				if member != nil {
+					chain = append(chain, fmt.Sprint(member))
+					walk(member)
+				}
+			}
+		default:
+			if wrapped := errors.Unwrap(err); wrapped != nil {
+				chain = append(chain, fmt.Sprint(wrapped))
+				walk(wrapped)
+			}
+		}
+	}
+	walk(err)
+
+	return chain
+}
+
+// errorStack returns the stack trace of the innermost error in the chain of err that
+// has a StackTrace method, rendered one "function file:line" entry per frame.
+func errorStack(err error) []string {
+	var stack []string
+
+	var walk func(error)
+	walk = func(err error) {
+		if isNilError(err) {
+			return
+		}
+
+		if frames, ok := stackTraceFrames(err); ok {
+			stack = frames
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, member := range e.Unwrap() {
+				if member != nil {
+					walk(member)
+				}
+			}
+		default:
+			if wrapped := errors.Unwrap(err); wrapped != nil {
+				walk(wrapped)
+			}
+		}
+	}
+	walk(err)
+
+	return stack
+}
+
+// stackTraceFrames calls the StackTrace method of err if it has one. The method is found
+// by reflection so that no particular errors package is required, each frame is formatted
+// with %+v as github.com/pkg/errors frames print as "function\n\tfile:line".
+func stackTraceFrames(err error) ([]string, bool) {
+	if isNilError(err) {
+		return nil, false
+	}
+
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil, false
+	}
+
+	trace := method.Call(nil)[0]
+	if trace.Kind() != reflect.Slice || trace.Len() == 0 {
+		return nil, false
+	}
+
+	frames := make([]string, 0, trace.Len())
+	for i := 0; i < trace.Len(); i++ {
+		frame := fmt.Sprintf("%+v", trace.Index(i).Interface())
+		frames = append(frames, strings.ReplaceAll(frame, "\n\t", " "))
+	}
+
+	return frames, true
+}
+
+// isNilError reports whether err is a nil pointer stored in a non nil error, its methods
+// usually panic when called.
+func isNilError(err error) bool {
+	v := reflect.ValueOf(err)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
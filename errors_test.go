@@ -0,0 +1,133 @@
+package logrus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// stackFrame mimics a github.com/pkg/errors Frame when formatted with %+v.
+type stackFrame string
+
+func (f stackFrame) Format(s fmt.State, verb rune) {
+	fmt.Fprintf(s, "main.%s\n\t/src/main.go:42", string(f))
+}
+
+type stackError struct {
+	msg string
+}
+
+func (e *stackError) Error() string { return e.msg }
+
+func (e *stackError) StackTrace() []stackFrame {
+	return []stackFrame{"load", "main"}
+}
+
+// fieldError dereferences its receiver, calling Error on a nil *fieldError panics.
+type fieldError struct {
+	field string
+}
+
+func (e *fieldError) Error() string { return "invalid " + e.field }
+
+func TestWithErrorNilPointer(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+
+	var err *fieldError
+	logger.WithError(err).Info("typed nil")
+	logger.WithError(errors.Join(errors.New("first"), err)).Info("joined")
+
+	output := buf.String()
+	if !strings.Contains(output, `msg="typed nil" error=<nil>`) {
+		t.Errorf("Expected <nil> for a nil pointer error: %s", output)
+	}
+	if !strings.Contains(output, `error.chain="first; <nil>"`) {
+		t.Errorf("Expected <nil> in the chain of a joined error: %s", output)
+	}
+}
+
+func TestWithErrorPlain(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+
+	logger.WithError(errors.New("boom")).Error("failed")
+
+	output := buf.String()
+	if !strings.Contains(output, "error=boom") {
+		t.Errorf("Expected plain error in output: %s", output)
+	}
+	if strings.Contains(output, "error.chain") || strings.Contains(output, "error.stack") {
+		t.Errorf("Did not expect chain or stack for a plain error: %s", output)
+	}
+}
+
+func TestWithErrorChainText(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+
+	base := errors.New("file does not exist")
+	err := fmt.Errorf("load config: %w", errors.Join(base, errors.New("permission denied")))
+	logger.WithError(err).Error("failed")
+
+	output := buf.String()
+	if !strings.Contains(output, `error.chain="file does not exist\npermission denied; file does not exist; permission denied"`) {
+		t.Errorf("Expected error chain in output: %s", output)
+	}
+}
+
+func TestWithErrorJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf, nil)
+
+	err := fmt.Errorf("load config: %w", &stackError{msg: "disk full"})
+	logger.WithError(err).Error("failed")
+
+	want := `"error":{"msg":"load config: disk full","chain":["disk full"],"stack":["main.load /src/main.go:42","main.main /src/main.go:42"]}`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected %s in output: %s", want, buf.String())
+	}
+
+	// plain and nil pointer errors are groups too, so the type of the error key is the same
+	buf.Reset()
+	var nilErr *fieldError
+	logger.WithError(errors.New("plain")).Error("failed")
+	logger.WithError(nilErr).Error("failed")
+	for _, want := range []string{`"error":{"msg":"plain"}`, `"error":{"msg":"<nil>"}`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %s in output: %s", want, buf.String())
+		}
+	}
+}
+
+func TestWithErrorStackText(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+
+	logger.WithError(&stackError{msg: "disk full"}).Error("failed")
+
+	if !strings.Contains(buf.String(), `error.stack="main.load /src/main.go:42; main.main /src/main.go:42"`) {
+		t.Errorf("Expected error stack in output: %s", buf.String())
+	}
+}
+
+func TestErrorKey(t *testing.T) {
+	defer func(key string) { ErrorKey = key }(ErrorKey)
+	ErrorKey = "err"
+
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	entry := logger.WithError(errors.New("boom"))
+	if entry.Data["err"] == nil {
+		t.Errorf("Expected error to be stored under ErrorKey: %v", entry.Data)
+	}
+
+	entry.Error("failed")
+	if !strings.Contains(buf.String(), "err=boom") {
+		t.Errorf("Expected error under configured key: %s", buf.String())
+	}
+}
@@ -0,0 +1,125 @@
+package logrus
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Command runs an exec.Cmd and logs its stdout and stderr line by line. Each line
+// carries the fields of the entry it was created from plus command, pid and stream.
+type Command struct {
+	// Cmd is the command being run, its Stdout and Stderr must not be set
+	Cmd *exec.Cmd
+
+	// Stdout determines the level of lines written to stdout
+	Stdout LevelSniffer
+
+	// Stderr determines the level of lines written to stderr
+	Stderr LevelSniffer
+
+	entry   *Entry
+	started time.Time
+	copies  sync.WaitGroup
+	writers []io.WriteCloser
+}
+
+// Command returns a Command that logs the output of cmd through the entry. By default
+// lines are logged at info level unless they carry a level prefix or are structured,
+// see DefaultLevelSniffer.
+func (entry *Entry) Command(cmd *exec.Cmd) *Command {
+	return &Command{
+		Cmd:    cmd,
+		Stdout: DefaultLevelSniffer(InfoLevel),
+		Stderr: DefaultLevelSniffer(InfoLevel),
+		entry:  entry.WithField("command", filepath.Base(cmd.Path)),
+	}
+}
+
+// Command returns a Command that logs the output of cmd, see Entry.Command.
+func (logger *Logger) Command(cmd *exec.Cmd) *Command {
+	return NewEntry(logger).Command(cmd)
+}
+
+// Run starts the command and waits for it to finish, see Start and Wait.
+func (c *Command) Run() error {
+	err := c.Start()
+	if err != nil {
+		return err
+	}
+
+	return c.Wait()
+}
+
+// Start starts the command and the logging of its output.
+func (c *Command) Start() error {
+	stdout, err := c.Cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := c.Cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	c.started = time.Now()
+	err = c.Cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	c.entry = c.entry.WithField("pid", c.Cmd.Process.Pid)
+	c.stream(stdout, "stdout", c.Stdout)
+	c.stream(stderr, "stderr", c.Stderr)
+
+	return nil
+}
+
+// Wait waits for the command to exit, logs all remaining output and then logs the exit
+// code and duration. It returns the error from exec.Cmd.Wait.
+func (c *Command) Wait() error {
+	// all reads from the pipes have to complete before calling Wait
+	c.copies.Wait()
+	err := c.Cmd.Wait()
+
+	for _, w := range c.writers {
+		w.Close()
+	}
+
+	exitCode := -1
+	if c.Cmd.ProcessState != nil {
+		exitCode = c.Cmd.ProcessState.ExitCode()
+	}
+
+	entry := c.entry.WithFields(Fields{
+		"exit_code": exitCode,
+		"duration":  time.Since(c.started),
+	})
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		entry.Info("Command finished")
+	case errors.As(err, &exitErr):
+		entry.Error("Command failed")
+	default:
+		entry.WithError(err).Error("Command failed")
+	}
+
+	return err
+}
+
+// stream logs lines read from r until it is closed.
+func (c *Command) stream(r io.Reader, name string, sniffer LevelSniffer) {
+	w := c.entry.WithField("stream", name).WriterSniffCloser(sniffer)
+	c.writers = append(c.writers, w)
+
+	c.copies.Add(1)
+	go func() {
+		defer c.copies.Done()
+		io.Copy(w, r)
+	}()
+}
@@ -0,0 +1,69 @@
+package logrus
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestCommandHelperProcess is run as the child process of the Command tests.
+func TestCommandHelperProcess(t *testing.T) {
+	if os.Getenv("SLOGRUS_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	fmt.Fprintln(os.Stdout, "hello from stdout")
+	fmt.Fprintln(os.Stderr, "ERROR: disk full")
+	fmt.Fprint(os.Stdout, "no trailing newline")
+	os.Exit(3)
+}
+
+func helperCommand() *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=TestCommandHelperProcess")
+	cmd.Env = append(os.Environ(), "SLOGRUS_HELPER_PROCESS=1")
+	return cmd
+}
+
+func TestCommand(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	cmd := helperCommand()
+	err := logger.WithField("job", "test").Command(cmd).Run()
+	if err == nil {
+		t.Fatal("Expected an error for a non zero exit code")
+	}
+
+	output := buf.String()
+	pid := fmt.Sprintf("pid=%d", cmd.Process.Pid)
+
+	for _, want := range []string{
+		`level=INFO msg="hello from stdout"`,
+		`level=ERROR msg="ERROR: disk full"`,
+		`msg="no trailing newline"`,
+		"stream=stdout",
+		"stream=stderr",
+		"job=test",
+		pid,
+		`level=ERROR msg="Command failed"`,
+		"exit_code=3",
+		"duration=",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output: %s", want, output)
+		}
+	}
+}
+
+func TestCommandStdoutAlreadySet(t *testing.T) {
+	cmd := helperCommand()
+	cmd.Stdout = &bytes.Buffer{}
+
+	if err := New().Command(cmd).Start(); err == nil {
+		t.Error("Expected Start() to fail when Stdout is already set")
+	}
+}
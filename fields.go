@@ -0,0 +1,101 @@
+package logrus
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// MaxFieldDepth limits how deeply nested Fields, maps and groups are rendered as groups,
+// values nested deeper are rendered using their default format.
+var MaxFieldDepth = 8
+
+// attrConverter converts attributes before they are passed to the output handler.
+type attrConverter struct {
+	json     bool
+	redactor *redactor
+}
+
+// needsConversion reports whether a has a value that appendAttr renders differently
+// from the slog handlers.
+func needsConversion(a slog.Attr) bool {
+	switch a.Value.Kind() {
+	case slog.KindLogValuer:
+		return true
+	case slog.KindAny:
+		switch a.Value.Any().(type) {
+		case Redactor, error, Fields, map[string]any:
+			return true
+		}
+	case slog.KindGroup:
+		for _, ga := range a.Value.Group() {
+			if needsConversion(ga) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// appendAttr appends a to attrs after resolving slog.LogValuer values, turning nested
+// Fields and maps into groups, rendering errors, see appendErrorAttrs, and applying
+// redaction when configured.
+func (c attrConverter) appendAttr(attrs []slog.Attr, a slog.Attr, depth int) []slog.Attr {
+	if c.redactor != nil && c.redactor.redactsKey(a.Key) {
+		return append(attrs, slog.String(a.Key, c.redactor.replacement))
+	}
+
+	value := redactValue(a.Value).Resolve()
+
+	switch value.Kind() {
+	case slog.KindGroup:
+		if depth >= MaxFieldDepth {
+			return append(attrs, slog.String(a.Key, fmt.Sprint(value.Any())))
+		}
+
+		group := value.Group()
+		converted := make([]slog.Attr, 0, len(group))
+		for _, ga := range group {
+			converted = c.appendAttr(converted, ga, depth+1)
+		}
+		return append(attrs, slog.Attr{Key: a.Key, Value: slog.GroupValue(converted...)})
+
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case error:
+			if v != nil {
+				return c.appendErrorAttrs(attrs, a.Key, v)
+			}
+		case Fields:
+			return c.appendMapAttrs(attrs, a.Key, v, depth)
+		case map[string]any:
+			return c.appendMapAttrs(attrs, a.Key, v, depth)
+		}
+	case slog.KindString:
+		if c.redactor != nil {
+			value = slog.StringValue(c.redactor.redactString(value.String()))
+		}
+	}
+
+	return append(attrs, slog.Attr{Key: a.Key, Value: value})
+}
+
+// appendMapAttrs appends m as a group named key with its keys in sorted order.
+func (c attrConverter) appendMapAttrs(attrs []slog.Attr, key string, m map[string]any, depth int) []slog.Attr {
+	if depth >= MaxFieldDepth {
+		return append(attrs, slog.String(key, fmt.Sprint(m)))
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	group := make([]slog.Attr, 0, len(m))
+	for _, k := range keys {
+		group = c.appendAttr(group, slog.Any(k, m[k]), depth+1)
+	}
+
+	return append(attrs, slog.Attr{Key: key, Value: slog.GroupValue(group...)})
+}
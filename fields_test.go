@@ -0,0 +1,83 @@
+package logrus
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type userValue struct {
+	name string
+}
+
+func (u userValue) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("name", u.name), slog.Any("meta", Fields{"admin": true}))
+}
+
+func TestNestedFieldsText(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+
+	logger.WithField("req", Fields{
+		"method": "GET",
+		"client": map[string]any{"ip": "192.168.1.1"},
+	}).Info("nested")
+
+	output := buf.String()
+	if !strings.Contains(output, "req.client.ip=192.168.1.1 req.method=GET") {
+		t.Errorf("Expected dotted keys for nested fields: %s", output)
+	}
+}
+
+func TestNestedFieldsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf, nil)
+
+	logger.WithField("req", Fields{"method": "GET", "status": 200}).Info("nested")
+
+	if !strings.Contains(buf.String(), `"req":{"method":"GET","status":200}`) {
+		t.Errorf("Expected nested object for nested fields: %s", buf.String())
+	}
+}
+
+func TestLogValuerResolved(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf, nil)
+
+	logger.WithField("user", userValue{name: "bob"}).Info("valuer")
+	logger.GetSlogLogger().Info("slog valuer", "user", userValue{name: "alice"})
+
+	output := buf.String()
+	if !strings.Contains(output, `"user":{"name":"bob","meta":{"admin":true}}`) {
+		t.Errorf("Expected resolved LogValuer with nested fields from entry: %s", output)
+	}
+	if !strings.Contains(output, `"user":{"name":"alice","meta":{"admin":true}}`) {
+		t.Errorf("Expected resolved LogValuer with nested fields from slog: %s", output)
+	}
+}
+
+func TestNestedFieldsSlogWith(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+
+	logger.GetSlogLogger().With("req", Fields{"id": 7}).Info("with")
+
+	if !strings.Contains(buf.String(), "req.id=7") {
+		t.Errorf("Expected nested fields from slog With: %s", buf.String())
+	}
+}
+
+func TestMaxFieldDepth(t *testing.T) {
+	defer func(depth int) { MaxFieldDepth = depth }(MaxFieldDepth)
+	MaxFieldDepth = 2
+
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+
+	logger.WithField("a", Fields{"b": Fields{"c": Fields{"d": 1}}}).Info("deep")
+
+	if !strings.Contains(buf.String(), `a.b.c=map[d:1]`) {
+		t.Errorf("Expected values beyond the depth limit in default format: %s", buf.String())
+	}
+}
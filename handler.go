@@ -0,0 +1,136 @@
+package logrus
+
+import (
+	"context"
+	"log/slog"
+)
+
+// entryHandler is a slog.Handler that converts slog records into entries of a Logger,
+// so that slog and logrus style calls share the logger's level, fields and output.
+type entryHandler struct {
+	logger *Logger
+	attrs  []slog.Attr
+	groups []string
+}
+
+// Handler returns a slog.Handler that routes slog records through the logger. Records
+// are subject to the logger's level and are written with the same field handling as
+// entries, use it with slog.New or SetAsSlogDefault.
+func (logger *Logger) Handler() slog.Handler {
+	return &entryHandler{logger: logger}
+}
+
+// SetAsSlogDefault makes the logger the destination of the slog package-level functions
+// and of the standard library log package. The logger must not itself write to the
+// default slog handler, as that would route records back into the logger.
+func (logger *Logger) SetAsSlogDefault() {
+	slog.SetDefault(slog.New(logger.Handler()))
+}
+
+// SetAsSlogDefault makes the standard logger the default slog logger.
+func SetAsSlogDefault() {
+	standardLogger.SetAsSlogDefault()
+}
+
+// Enabled reports whether the logger logs records at the given level.
+func (h *entryHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.IsLevelEnabled(levelFromSlog(level))
+}
+
+// Handle converts r into an entry and writes it to the logger's output.
+func (h *entryHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.logger.sample(levelFromSlog(r.Level), r.Message) {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = backgroundContext
+	}
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	fields := make(Fields, len(h.attrs)+len(attrs))
+	for _, a := range h.attrs {
+		addAttrField(fields, a)
+	}
+	for _, a := range groupAttrs(h.groups, attrs) {
+		addAttrField(fields, a)
+	}
+
+	entry := NewEntry(h.logger)
+	entry.Data = fields
+	entry.Time = r.Time
+	entry.Context = ctx
+
+	return entry.writeRecord(r.Level, r.PC, r.Message)
+}
+
+// WithAttrs returns a handler that adds attrs to every record.
+func (h *entryHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	merged := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	merged = append(merged, h.attrs...)
+	merged = append(merged, groupAttrs(h.groups, attrs)...)
+
+	return &entryHandler{logger: h.logger, attrs: merged, groups: h.groups}
+}
+
+// WithGroup returns a handler that nests further attributes under name.
+func (h *entryHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	groups := make([]string, 0, len(h.groups)+1)
+	groups = append(groups, h.groups...)
+	groups = append(groups, name)
+
+	return &entryHandler{logger: h.logger, attrs: h.attrs, groups: groups}
+}
+
+// groupAttrs nests attrs inside the given groups, outermost first.
+func groupAttrs(groups []string, attrs []slog.Attr) []slog.Attr {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	for i := len(groups) - 1; i >= 0; i-- {
+		attrs = []slog.Attr{{Key: groups[i], Value: slog.GroupValue(attrs...)}}
+	}
+
+	return attrs
+}
+
+// addAttrField stores a in fields, merging groups that share a key and inlining
+// groups without a key as slog handlers do.
+func addAttrField(fields Fields, a slog.Attr) {
+	if a.Value.Kind() != slog.KindGroup {
+		if a.Key != "" {
+			fields[a.Key] = a.Value.Any()
+		}
+		return
+	}
+
+	group := a.Value.Group()
+	if a.Key == "" {
+		for _, ga := range group {
+			addAttrField(fields, ga)
+		}
+		return
+	}
+
+	if existing, ok := fields[a.Key].([]slog.Attr); ok {
+		merged := make([]slog.Attr, 0, len(existing)+len(group))
+		merged = append(merged, existing...)
+		group = append(merged, group...)
+	}
+
+	fields[a.Key] = group
+}
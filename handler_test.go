@@ -0,0 +1,82 @@
+package logrus
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLoggerHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+	logger.AddContextExtractor(requestIDExtractor)
+
+	slogger := slog.New(logger.Handler()).With("service", "api")
+
+	ctx := context.WithValue(context.Background(), contextTestKey("request_id"), "req-1")
+	slogger.InfoContext(ctx, "via handler", "user", "bob")
+	slogger.Debug("debug via handler")
+
+	output := buf.String()
+	for _, want := range []string{"via handler", "service=api", "user=bob", "request_id=req-1"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output: %s", want, output)
+		}
+	}
+	if strings.Contains(output, "debug via handler") {
+		t.Errorf("Debug message should be filtered by the logger level: %s", output)
+	}
+
+	buf.Reset()
+	logger.SetLevel(DebugLevel)
+	slog.New(logger.Handler()).Debug("debug enabled")
+	if !strings.Contains(buf.String(), "debug enabled") {
+		t.Errorf("Expected debug message after SetLevel: %s", buf.String())
+	}
+}
+
+func TestLoggerHandlerGroups(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf, nil)
+
+	slogger := slog.New(logger.Handler()).WithGroup("req").With("method", "GET")
+	slogger.Info("grouped", "path", "/api")
+
+	output := buf.String()
+	if !strings.Contains(output, `"req":{"method":"GET","path":"/api"}`) {
+		t.Errorf("Expected merged group in output: %s", output)
+	}
+}
+
+func TestLoggerHandlerKeepsSource(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{AddSource: true})
+
+	slog.New(logger.Handler()).Info("with source")
+
+	if !strings.Contains(buf.String(), "handler_test.go") {
+		t.Errorf("Expected caller source in output: %s", buf.String())
+	}
+}
+
+func TestSetAsSlogDefault(t *testing.T) {
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+	logger.SetAsSlogDefault()
+
+	slog.Info("through default", "key", "value")
+	logger.Info("through logger")
+
+	output := buf.String()
+	if !strings.Contains(output, "through default") || !strings.Contains(output, "key=value") {
+		t.Errorf("Expected slog default output: %s", output)
+	}
+	if !strings.Contains(output, "through logger") {
+		t.Errorf("Expected logger output: %s", output)
+	}
+}
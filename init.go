@@ -16,35 +16,22 @@ func NewTextLogger(w io.Writer, opts *slog.HandlerOptions) *Logger {
 			Level: slog.LevelInfo,
 		}
 	}
-	handler := slog.NewTextHandler(w, opts)
+	handler := newFormatterHandler(&TextFormatter{}, w, opts)
 
 	// Determine our internal Level based on slog handler Level
 	var internalLevel Level = InfoLevel
 	if opts.Level != nil {
-		switch {
-		case opts.Level.Level() <= slog.LevelDebug-4:
-			internalLevel = TraceLevel
-		case opts.Level.Level() <= slog.LevelDebug:
-			internalLevel = DebugLevel
-		case opts.Level.Level() <= slog.LevelInfo:
-			internalLevel = InfoLevel
-		case opts.Level.Level() <= slog.LevelWarn:
-			internalLevel = WarnLevel
-		case opts.Level.Level() <= slog.LevelError:
-			internalLevel = ErrorLevel
-		case opts.Level.Level() <= slog.LevelError+4:
-			internalLevel = FatalLevel
-		default:
-			internalLevel = PanicLevel
-		}
+		internalLevel = levelFromSlog(opts.Level.Level())
 	}
 
-	return &Logger{
-		slogger:   slog.New(handler),
-		Level:     internalLevel,
-		Out:       w,
-		Formatter: &TextFormatter{},
+	logger := &Logger{
+		Level:        internalLevel,
+		Out:          w,
+		Formatter:    &TextFormatter{},
+		reportCaller: opts.AddSource,
 	}
+	logger.setHandler(handler)
+	return logger
 }
 
 // NewJSONLogger creates a new Logger with a JSON handler.
@@ -57,59 +44,72 @@ func NewJSONLogger(w io.Writer, opts *slog.HandlerOptions) *Logger {
 			Level: slog.LevelInfo,
 		}
 	}
-	handler := slog.NewJSONHandler(w, opts)
+	handler := newFormatterHandler(&JSONFormatter{}, w, opts)
 
 	// Determine our internal Level based on slog handler Level
 	var internalLevel Level = InfoLevel
 	if opts.Level != nil {
-		switch {
-		case opts.Level.Level() <= slog.LevelDebug-4:
-			internalLevel = TraceLevel
-		case opts.Level.Level() <= slog.LevelDebug:
-			internalLevel = DebugLevel
-		case opts.Level.Level() <= slog.LevelInfo:
-			internalLevel = InfoLevel
-		case opts.Level.Level() <= slog.LevelWarn:
-			internalLevel = WarnLevel
-		case opts.Level.Level() <= slog.LevelError:
-			internalLevel = ErrorLevel
-		case opts.Level.Level() <= slog.LevelError+4:
-			internalLevel = FatalLevel
-		default:
-			internalLevel = PanicLevel
-		}
+		internalLevel = levelFromSlog(opts.Level.Level())
 	}
 
-	return &Logger{
-		slogger:   slog.New(handler),
-		Level:     internalLevel,
-		Out:       w,
-		Formatter: &JSONFormatter{},
+	logger := &Logger{
+		Level:        internalLevel,
+		Out:          w,
+		Formatter:    &JSONFormatter{},
+		reportCaller: opts.AddSource,
 	}
+	logger.setHandler(handler)
+	return logger
 }
 
 // SetFormatter is a compatibility function for logrus that allows switching between text and JSON formatters.
 // It recreates the standard logger with the appropriate handler.
 func SetFormatter(formatter Formatter) {
-	var handler slog.Handler
-	opts := &slog.HandlerOptions{
-		Level: standardLogger.Level.toSlogLevel(),
-	}
+	standardLogger.SetFormatter(formatter)
+}
+
+// SetFormatter switches the logger between text and JSON output, it replaces the
+// handler with a new one writing to Out.
+func (logger *Logger) SetFormatter(formatter Formatter) {
+	logger.handlerMu.Lock()
+	defer logger.handlerMu.Unlock()
 
 	switch formatter.(type) {
-	case *TextFormatter:
-		handler = slog.NewTextHandler(standardLogger.Out, opts)
-		standardLogger.Formatter = formatter
-	case *JSONFormatter:
-		handler = slog.NewJSONHandler(standardLogger.Out, opts)
-		standardLogger.Formatter = formatter
+	case *TextFormatter, *JSONFormatter:
+		logger.Formatter = formatter
 	default:
 		// Default to text handler
-		handler = slog.NewTextHandler(standardLogger.Out, opts)
-		standardLogger.Formatter = &TextFormatter{}
+		logger.Formatter = &TextFormatter{}
 	}
 
-	standardLogger.slogger = slog.New(handler)
+	logger.setHandler(newFormatterHandler(logger.Formatter, logger.Out, logger.handlerOptions()))
+}
+
+// newFormatterHandler creates the slog handler writing the output of formatter to out,
+// a SplitOutput gets a handler per writer.
+func newFormatterHandler(formatter Formatter, out io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	if split, ok := out.(*SplitOutput); ok {
+		return newSplitHandler(formatter, split, opts)
+	}
+	if _, ok := formatter.(*JSONFormatter); ok {
+		return slog.NewJSONHandler(out, opts)
+	}
+	return slog.NewTextHandler(out, opts)
+}
+
+// handlerFormatter returns the formatter matching a handler created by this package,
+// it returns false for other handlers.
+func handlerFormatter(handler slog.Handler) (Formatter, bool) {
+	switch h := handler.(type) {
+	case *slog.TextHandler:
+		return &TextFormatter{}, true
+	case *slog.JSONHandler:
+		return &JSONFormatter{}, true
+	case *splitHandler:
+		return h.formatter, true
+	default:
+		return nil, false
+	}
 }
 
 // Formatter interface for logrus compatibility.
@@ -149,21 +149,23 @@ func (f *JSONFormatter) Format(entry *Entry) ([]byte, error) {
 
 // SetReportCaller enables or disables caller reporting for the standard logger.
 func SetReportCaller(include bool) {
-	// Create new handler options with caller reporting
-	opts := &slog.HandlerOptions{
-		Level:     standardLogger.Level.toSlogLevel(),
-		AddSource: include,
-	}
+	standardLogger.SetReportCaller(include)
+}
+
+// SetReportCaller enables or disables caller reporting, it is kept when the output or
+// level change.
+func (logger *Logger) SetReportCaller(include bool) {
+	logger.handlerMu.Lock()
+	defer logger.handlerMu.Unlock()
+
+	logger.reportCaller = include
 
 	// Recreate the handler based on current type
-	var handler slog.Handler
-	if _, ok := standardLogger.slogger.Handler().(*slog.JSONHandler); ok {
-		handler = slog.NewJSONHandler(standardLogger.Out, opts)
-		standardLogger.Formatter = &JSONFormatter{}
-	} else {
-		handler = slog.NewTextHandler(standardLogger.Out, opts)
-		standardLogger.Formatter = &TextFormatter{}
+	formatter, ok := handlerFormatter(logger.handler)
+	if !ok {
+		formatter = &TextFormatter{}
 	}
 
-	standardLogger.slogger = slog.New(handler)
+	logger.Formatter = formatter
+	logger.setHandler(newFormatterHandler(formatter, logger.Out, logger.handlerOptions()))
 }
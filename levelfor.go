@@ -0,0 +1,126 @@
+package logrus
+
+import (
+	"context"
+	"time"
+)
+
+// levelWindow is a temporary level set with SetLevelFor or SetLevelForContext.
+type levelWindow struct {
+	level    Level
+	deadline time.Time
+	timer    *time.Timer
+	stopCtx  func() bool
+}
+
+// SetLevelFor sets the level for duration d and then restores the previous level. While a
+// window is active another call with the same level extends it, a call with a different
+// level is stacked on top of it and when it ends the level of the window below applies
+// again. The level from before the first window is restored when all windows have ended.
+// The start and end of each window are logged at info level, also when the logger is
+// less verbose.
+func (logger *Logger) SetLevelFor(level Level, d time.Duration) {
+	if d > 0 {
+		logger.startLevelWindow(nil, level, d)
+	}
+}
+
+// SetLevelFor sets the level of the standard logger for duration d.
+func SetLevelFor(level Level, d time.Duration) {
+	standardLogger.SetLevelFor(level, d)
+}
+
+// SetLevelForContext is like SetLevelFor but the level is also restored when ctx is done,
+// a duration of 0 restores it only when ctx is done. The call is ignored when neither
+// ends the window, a nil ctx or one that is never done with a duration of 0.
+func (logger *Logger) SetLevelForContext(ctx context.Context, level Level, d time.Duration) {
+	if d <= 0 && (ctx == nil || ctx.Done() == nil) {
+		return
+	}
+
+	logger.startLevelWindow(ctx, level, d)
+}
+
+// SetLevelForContext sets the level of the standard logger until ctx is done or d passed.
+func SetLevelForContext(ctx context.Context, level Level, d time.Duration) {
+	standardLogger.SetLevelForContext(ctx, level, d)
+}
+
+// startLevelWindow starts a window at level ending after d when positive and when ctx
+// is done when not nil.
+func (logger *Logger) startLevelWindow(ctx context.Context, level Level, d time.Duration) {
+	logger.windowMu.Lock()
+	defer logger.windowMu.Unlock()
+
+	current := logger.GetLevel()
+	if len(logger.windows) == 0 {
+		logger.windowBase = current
+	}
+
+	if ctx == nil && d > 0 && len(logger.windows) > 0 {
+		top := logger.windows[len(logger.windows)-1]
+		if top.level == level && top.stopCtx == nil && top.timer != nil {
+			deadline := time.Now().Add(d)
+			if deadline.After(top.deadline) {
+				top.deadline = deadline
+				top.timer.Reset(d)
+			}
+			logger.WithFields(Fields{"new_level": level.String(), "until": top.deadline}).logNotice("Temporary log level extended")
+			return
+		}
+	}
+
+	w := &levelWindow{level: level}
+	if d > 0 {
+		w.deadline = time.Now().Add(d)
+		w.timer = time.AfterFunc(d, func() { logger.endLevelWindow(w) })
+	}
+	if ctx != nil {
+		w.stopCtx = context.AfterFunc(ctx, func() { logger.endLevelWindow(w) })
+	}
+	logger.windows = append(logger.windows, w)
+
+	fields := Fields{}
+	if d > 0 {
+		fields["duration"] = d.String()
+	}
+	logger.logLevelChange(current, level, "Temporary log level set", fields)
+}
+
+// endLevelWindow removes w and restores the level of the window below it, or the level
+// from before the first window.
+func (logger *Logger) endLevelWindow(w *levelWindow) {
+	logger.windowMu.Lock()
+	defer logger.windowMu.Unlock()
+
+	i := -1
+	for j, window := range logger.windows {
+		if window == w {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return
+	}
+
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	if w.stopCtx != nil {
+		w.stopCtx()
+	}
+
+	top := i == len(logger.windows)-1
+	logger.windows = append(logger.windows[:i], logger.windows[i+1:]...)
+	if !top {
+		return
+	}
+
+	level := logger.windowBase
+	if len(logger.windows) > 0 {
+		level = logger.windows[len(logger.windows)-1].level
+	}
+
+	logger.logLevelChange(logger.GetLevel(), level, "Temporary log level ended", Fields{})
+}
@@ -0,0 +1,128 @@
+package logrus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func waitForLevel(t *testing.T, logger *Logger, want Level) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for logger.GetLevel() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("level = %v, want %v", logger.GetLevel(), want)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+func TestSetLevelFor(t *testing.T) {
+	buf := &syncBuffer{}
+	logger := New()
+	logger.SetOutput(buf)
+
+	logger.SetLevelFor(TraceLevel, 30*time.Millisecond)
+	if logger.GetLevel() != TraceLevel {
+		t.Fatalf("level = %v, want %v", logger.GetLevel(), TraceLevel)
+	}
+
+	waitForLevel(t, logger, InfoLevel)
+
+	output := buf.String()
+	if !strings.Contains(output, `msg="Temporary log level set"`) || !strings.Contains(output, "duration=30ms") {
+		t.Errorf("start was not logged:\n%s", output)
+	}
+	if !strings.Contains(output, `msg="Temporary log level ended"`) {
+		t.Errorf("end was not logged:\n%s", output)
+	}
+}
+
+func TestSetLevelForOverlapping(t *testing.T) {
+	buf := &syncBuffer{}
+	logger := New()
+	logger.SetOutput(buf)
+
+	// the same level extends the window
+	logger.SetLevelFor(DebugLevel, 20*time.Millisecond)
+	logger.SetLevelFor(DebugLevel, time.Hour)
+	time.Sleep(50 * time.Millisecond)
+	if logger.GetLevel() != DebugLevel {
+		t.Fatalf("extended window ended early, level = %v", logger.GetLevel())
+	}
+	if !strings.Contains(buf.String(), `level=INFO msg="Temporary log level extended" new_level=debug`) {
+		t.Errorf("extension was not logged:\n%s", buf.String())
+	}
+
+	// a different level is stacked and restores the window below
+	logger.SetLevelFor(TraceLevel, 20*time.Millisecond)
+	if logger.GetLevel() != TraceLevel {
+		t.Fatalf("level = %v, want %v", logger.GetLevel(), TraceLevel)
+	}
+	waitForLevel(t, logger, DebugLevel)
+
+	logger.windowMu.Lock()
+	windows := len(logger.windows)
+	logger.windowMu.Unlock()
+	if windows != 1 {
+		t.Errorf("%d windows active, want 1", windows)
+	}
+}
+
+func TestSetLevelForContext(t *testing.T) {
+	logger := New()
+	logger.SetOutput(&syncBuffer{})
+
+	// windows that would never end are ignored
+	logger.SetLevelForContext(nil, TraceLevel, 0)
+	logger.SetLevelForContext(context.Background(), TraceLevel, 0)
+	if logger.GetLevel() != InfoLevel {
+		t.Fatalf("level = %v, want %v", logger.GetLevel(), InfoLevel)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logger.SetLevelForContext(ctx, TraceLevel, 0)
+	inner, cancelInner := context.WithCancel(context.Background())
+	logger.SetLevelForContext(inner, DebugLevel, time.Hour)
+
+	// ending the lower window keeps the level of the window on top
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	if logger.GetLevel() != DebugLevel {
+		t.Fatalf("level = %v, want %v", logger.GetLevel(), DebugLevel)
+	}
+
+	cancelInner()
+	waitForLevel(t, logger, InfoLevel)
+}
+
+func TestSetLevelForConcurrentLogging(t *testing.T) {
+	logger := New()
+	logger.SetOutput(&syncBuffer{})
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				logger.WithError(errors.New("boom")).Info("while levels change")
+			}
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		logger.SetLevelFor(DebugLevel, time.Millisecond)
+		time.Sleep(2 * time.Millisecond)
+	}
+	close(stop)
+	<-done
+
+	waitForLevel(t, logger, InfoLevel)
+}
@@ -0,0 +1,178 @@
+package logrus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LevelState is the response of the handler returned by LevelHandler.
+type LevelState struct {
+	// Level is the level of the logger
+	Level string `json:"level"`
+
+	// Loggers are the effective levels of the loggers created with Named
+	Loggers map[string]string `json:"loggers"`
+}
+
+// LevelChange is the request body accepted by the handler returned by LevelHandler.
+type LevelChange struct {
+	// Logger is the named logger to change, the logger itself when empty
+	Logger string `json:"logger,omitempty"`
+
+	// Level is the new level as understood by ParseLevel, in any case
+	Level string `json:"level"`
+
+	// TTL reverts the change after a duration such as "15m" when set
+	TTL string `json:"ttl,omitempty"`
+}
+
+// maxLevelChangeSize limits the size of a LevelChange request body.
+const maxLevelChangeSize = 4096
+
+// levelHandler serves the levels of a logger over HTTP.
+type levelHandler struct {
+	logger *Logger
+
+	mu      sync.Mutex
+	reverts map[string]*levelRevert
+}
+
+// levelRevert is a pending revert of a change made with a TTL.
+type levelRevert struct {
+	timer *time.Timer
+	level Level
+	rule  bool
+}
+
+// LevelHandler returns an http.Handler that shows the levels of the logger and its named
+// loggers as JSON on GET, and changes them on PUT or POST with a LevelChange body such as
+// {"logger":"federation","level":"debug","ttl":"10m"}. The response is the LevelState
+// after the change.
+func (logger *Logger) LevelHandler() http.Handler {
+	return &levelHandler{logger: logger, reverts: make(map[string]*levelRevert)}
+}
+
+// LevelHandler returns an http.Handler for the levels of the standard logger.
+func LevelHandler() http.Handler {
+	return standardLogger.LevelHandler()
+}
+
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut, http.MethodPost:
+		err := h.change(w, r)
+		if err != nil {
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+		h.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, h.state())
+}
+
+// change applies the LevelChange in the body of r.
+func (h *levelHandler) change(w http.ResponseWriter, r *http.Request) error {
+	var change LevelChange
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLevelChangeSize))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&change)
+	if err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	level, err := ParseLevel(strings.ToLower(strings.TrimSpace(change.Level)))
+	if err != nil {
+		return err
+	}
+
+	var ttl time.Duration
+	if change.TTL != "" {
+		ttl, err = time.ParseDuration(change.TTL)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid ttl %q", change.TTL)
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// a change replaces a pending revert, still reverting to the level from before both
+	revert, pending := h.reverts[change.Logger]
+	if pending {
+		revert.timer.Stop()
+		delete(h.reverts, change.Logger)
+	} else {
+		revert = h.current(change.Logger)
+	}
+
+	h.set(change.Logger, level, true)
+
+	if ttl > 0 {
+		name := change.Logger
+		revert.timer = time.AfterFunc(ttl, func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			if h.reverts[name] != revert {
+				return
+			}
+			delete(h.reverts, name)
+			h.set(name, revert.level, revert.rule)
+		})
+		h.reverts[name] = revert
+	}
+
+	return nil
+}
+
+// current returns a levelRevert restoring the present level of the named logger.
+func (h *levelHandler) current(name string) *levelRevert {
+	if name == "" {
+		return &levelRevert{level: h.logger.GetLevel(), rule: true}
+	}
+
+	level, ok := h.logger.namedRule(name)
+
+	return &levelRevert{level: level, rule: ok}
+}
+
+// set sets the level of the named logger, or removes its rule when rule is false.
+func (h *levelHandler) set(name string, level Level, rule bool) {
+	switch {
+	case name == "":
+		h.logger.SetLevel(level)
+	case rule:
+		h.logger.SetNamedLevel(name, level)
+	default:
+		h.logger.ResetNamedLevel(name)
+	}
+}
+
+// state returns the current levels.
+func (h *levelHandler) state() LevelState {
+	state := LevelState{
+		Level:   h.logger.GetLevel().String(),
+		Loggers: make(map[string]string),
+	}
+	for _, name := range h.logger.NamedLoggers() {
+		state.Loggers[name] = h.logger.NamedLevel(name).String()
+	}
+
+	return state
+}
+
+func (h *levelHandler) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,166 @@
+package logrus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func levelRequest(t *testing.T, handler http.Handler, method string, body string) (int, LevelState) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, "/loglevel", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var state LevelState
+	if rec.Code == http.StatusOK {
+		err := json.Unmarshal(rec.Body.Bytes(), &state)
+		if err != nil {
+			t.Fatalf("invalid response %q: %v", rec.Body.String(), err)
+		}
+	}
+
+	return rec.Code, state
+}
+
+func TestLevelHandler(t *testing.T) {
+	logger := New()
+	logger.Named("federation").Named("broker")
+	logger.SetNamedLevel("federation", DebugLevel)
+
+	handler := logger.LevelHandler()
+
+	code, state := levelRequest(t, handler, http.MethodGet, "")
+	if code != http.StatusOK || state.Level != "info" {
+		t.Fatalf("GET = %d %+v", code, state)
+	}
+	if state.Loggers["federation"] != "debug" || state.Loggers["federation.broker"] != "debug" {
+		t.Errorf("GET loggers = %v", state.Loggers)
+	}
+
+	code, state = levelRequest(t, handler, http.MethodPut, `{"level":"warn"}`)
+	if code != http.StatusOK || state.Level != "warning" || logger.GetLevel() != WarnLevel {
+		t.Errorf("PUT level = %d %+v, logger level %v", code, state, logger.GetLevel())
+	}
+
+	code, state = levelRequest(t, handler, http.MethodPost, `{"logger":"federation.broker","level":"trace"}`)
+	if code != http.StatusOK || state.Loggers["federation.broker"] != "trace" || state.Loggers["federation"] != "debug" {
+		t.Errorf("POST named level = %d %+v", code, state)
+	}
+
+	code, state = levelRequest(t, handler, http.MethodPut, `{"level":" DEBUG "}`)
+	if code != http.StatusOK || state.Level != "debug" || logger.GetLevel() != DebugLevel {
+		t.Errorf("PUT upper case level = %d %+v, logger level %v", code, state, logger.GetLevel())
+	}
+
+	large := `{"level":"info","logger":"` + strings.Repeat("x", maxLevelChangeSize) + `"}`
+	for _, body := range []string{`{"level":"loud"}`, `{"level":"info","ttl":"soon"}`, `{"level":"info","extra":true}`, `not json`, large} {
+		if code, _ := levelRequest(t, handler, http.MethodPut, body); code != http.StatusBadRequest {
+			t.Errorf("PUT %.40s = %d, want %d", body, code, http.StatusBadRequest)
+		}
+	}
+
+	if code, _ := levelRequest(t, handler, http.MethodDelete, ""); code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE = %d, want %d", code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestLevelHandlerTTL(t *testing.T) {
+	logger := New()
+	logger.SetOutput(&syncBuffer{})
+	handler := logger.LevelHandler()
+
+	// log concurrently with the changes
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				logger.Named("worker").Debug("working")
+			}
+		}
+	}()
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	levelRequest(t, handler, http.MethodPut, `{"level":"trace","ttl":"1h"}`)
+	// replaces the pending revert, still reverting to info
+	levelRequest(t, handler, http.MethodPut, `{"level":"debug","ttl":"20ms"}`)
+	levelRequest(t, handler, http.MethodPut, `{"logger":"worker","level":"error","ttl":"20ms"}`)
+
+	if logger.GetLevel() != DebugLevel || logger.NamedLevel("worker") != ErrorLevel {
+		t.Fatalf("levels before the ttl = %v and %v", logger.GetLevel(), logger.NamedLevel("worker"))
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for logger.GetLevel() != InfoLevel || logger.NamedLevel("worker") != InfoLevel {
+		if time.Now().After(deadline) {
+			t.Fatalf("levels were not reverted: %v and %v", logger.GetLevel(), logger.NamedLevel("worker"))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, ok := logger.namedRule("worker"); ok {
+		t.Error("the rule of worker was not removed by the revert")
+	}
+}
+
+func TestLevelHandlerConcurrentChanges(t *testing.T) {
+	logger := New()
+	logger.SetOutput(&syncBuffer{})
+	handler := logger.LevelHandler()
+
+	done := make(chan struct{})
+	var logging sync.WaitGroup
+	logging.Add(1)
+	go func() {
+		defer logging.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				logger.Named("worker").WithError(errors.New("boom")).Info("working")
+			}
+		}
+	}()
+
+	var changes sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		changes.Add(1)
+		go func(i int) {
+			defer changes.Done()
+			levelRequest(t, handler, http.MethodPut, fmt.Sprintf(`{"logger":"component%d","level":"debug"}`, i))
+			levelRequest(t, handler, http.MethodPut, `{"level":"debug","ttl":"1ms"}`)
+		}(i)
+	}
+	changes.Wait()
+	close(done)
+	logging.Wait()
+
+	// no change was lost to a concurrent one
+	for i := 0; i < 10; i++ {
+		if level, ok := logger.namedRule(fmt.Sprintf("component%d", i)); !ok || level != DebugLevel {
+			t.Errorf("rule of component%d = %v, %v", i, level, ok)
+		}
+	}
+	waitForLevel(t, logger, InfoLevel)
+}
@@ -6,11 +6,15 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
 )
 
 // Logger is the main logging struct that wraps slog.Logger for logrus compatibility.
 type Logger struct {
-	slogger *slog.Logger
+	slogger atomic.Pointer[slog.Logger]
+	handler slog.Handler
 	Level   Level
 
 	// Out provides access to the configured output writer (logrus compatibility)
@@ -18,6 +22,42 @@ type Logger struct {
 
 	// Formatter stores the configured handler type (logrus compatibility)
 	Formatter Formatter
+
+	reportCaller bool
+
+	// handlerMu serializes changes of handler, Out, Formatter and reportCaller, levels
+	// change on timer, signal and HTTP goroutines while other goroutines log
+	handlerMu sync.Mutex
+
+	mu         sync.RWMutex
+	extractors []ContextExtractor
+
+	writerMaxLine   int
+	writerLongLines LongLineMode
+
+	redactor      atomic.Pointer[redactor]
+	defaultFields Fields
+	sampler       atomic.Pointer[sampler]
+	deduper       atomic.Pointer[deduper]
+	async         atomic.Pointer[asyncWriter]
+
+	sinks     []Sink
+	sinkLevel atomic.Uint32
+
+	// verboseLevel is the most verbose level enabled by the level, a sink or a rule
+	verboseLevel atomic.Uint32
+
+	levelRules   atomic.Pointer[levelRules]
+	packageRules atomic.Pointer[packageRules]
+	names        sync.Map
+
+	windowMu   sync.Mutex
+	windows    []*levelWindow
+	windowBase Level
+
+	configMu      sync.Mutex
+	configSinks   []string
+	configClosers []io.Closer
 }
 
 // New creates a new Logger instance with default text handler.
@@ -25,12 +65,13 @@ func New() *Logger {
 	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	})
-	return &Logger{
-		slogger:   slog.New(handler),
+	logger := &Logger{
 		Level:     InfoLevel,
 		Out:       os.Stderr,
 		Formatter: &TextFormatter{},
 	}
+	logger.setHandler(handler)
+	return logger
 }
 
 // NewWithHandler creates a new Logger with a custom slog.Handler.
@@ -40,12 +81,13 @@ func NewWithHandler(handler slog.Handler) *Logger {
 	if _, ok := handler.(*slog.JSONHandler); ok {
 		formatter = &JSONFormatter{}
 	}
-	return &Logger{
-		slogger:   slog.New(handler),
+	logger := &Logger{
 		Level:     InfoLevel,
 		Out:       os.Stderr,
 		Formatter: formatter,
 	}
+	logger.setHandler(handler)
+	return logger
 }
 
 // FromSlogLogger creates a new Logger instance from an existing slog.Logger.
@@ -56,58 +98,140 @@ func FromSlogLogger(slogger *slog.Logger) *Logger {
 	if _, ok := slogger.Handler().(*slog.JSONHandler); ok {
 		formatter = &JSONFormatter{}
 	}
-	return &Logger{
-		slogger:   slogger,
+	logger := &Logger{
 		Level:     InfoLevel, // Default Level, can be changed with SetLevel
 		Out:       os.Stderr, // Default output, may not match slog handler's output
 		Formatter: formatter,
 	}
+	logger.setHandler(slogger.Handler())
+	return logger
 }
 
 // SetOutput sets the output destination for the logger.
 func (logger *Logger) SetOutput(out io.Writer) {
+	logger.handlerMu.Lock()
+	defer logger.handlerMu.Unlock()
+
 	logger.Out = out
 	// Create a new handler with the new output
-	opts := &slog.HandlerOptions{
-		Level: logger.Level.toSlogLevel(),
-	}
+	opts := logger.handlerOptions()
 
-	if _, ok := logger.slogger.Handler().(*slog.TextHandler); ok {
-		logger.slogger = slog.New(slog.NewTextHandler(logger.Out, opts))
-		logger.Formatter = &TextFormatter{}
-	} else if _, ok := logger.slogger.Handler().(*slog.JSONHandler); ok {
-		logger.slogger = slog.New(slog.NewJSONHandler(logger.Out, opts))
-		logger.Formatter = &JSONFormatter{}
+	if formatter, ok := handlerFormatter(logger.handler); ok {
+		logger.Formatter = formatter
+		logger.setHandler(newFormatterHandler(formatter, logger.Out, opts))
 	}
 }
 
 // SetLevel sets the logging Level for the logger.
 func (logger *Logger) SetLevel(level Level) {
-	logger.Level = level
+	logger.handlerMu.Lock()
+	defer logger.handlerMu.Unlock()
+
+	atomic.StoreUint32((*uint32)(&logger.Level), uint32(level))
 	// Update the slog handler with new Level
-	opts := &slog.HandlerOptions{
-		Level: level.toSlogLevel(),
-	}
+	opts := logger.handlerOptions()
 
 	// Recreate handler with new Level
-	if _, ok := logger.slogger.Handler().(*slog.TextHandler); ok {
-		logger.slogger = slog.New(slog.NewTextHandler(logger.Out, opts))
-		logger.Formatter = &TextFormatter{}
-	} else if _, ok := logger.slogger.Handler().(*slog.JSONHandler); ok {
-		logger.slogger = slog.New(slog.NewJSONHandler(logger.Out, opts))
-		logger.Formatter = &JSONFormatter{}
+	if formatter, ok := handlerFormatter(logger.handler); ok {
+		logger.Formatter = formatter
+		logger.setHandler(newFormatterHandler(formatter, logger.Out, opts))
+	} else {
+		logger.updateVerboseLevel()
+	}
+}
+
+// updateVerboseLevel stores the most verbose level enabled by the level, the sinks or the
+// level rules, entries at more verbose levels are dropped without further checks.
+func (logger *Logger) updateVerboseLevel() {
+	logger.verboseLevel.Store(uint32(max(logger.handlerLevel(), Level(logger.sinkLevel.Load()))))
+}
+
+// handlerOptions returns the options for the handlers created by the logger.
+func (logger *Logger) handlerOptions() *slog.HandlerOptions {
+	return &slog.HandlerOptions{
+		Level:     logger.handlerLevel().toSlogLevel(),
+		AddSource: logger.reportCaller,
+	}
+}
+
+// setHandler installs handler as the output handler and rebuilds the slog.Logger
+// so that records pass through the logger's processing pipeline first.
+// The records are written to handler and the handlers of the sinks, with level rules
+// handler is limited to the level each entry was checked against. Callers hold handlerMu
+// unless the logger is still being constructed.
+func (logger *Logger) setHandler(handler slog.Handler) {
+	logger.handler = handler
+	_, json := logger.Formatter.(*JSONFormatter)
+
+	output := handler
+	if logger.hasLevelRules() {
+		output = &levelGate{logger: logger, next: handler}
+	}
+	if sinks := logger.sinkHandlers(); len(sinks) > 0 {
+		output = &fanoutHandler{handlers: append([]slog.Handler{output}, sinks...)}
+	}
+	logger.updateVerboseLevel()
+
+	var pipeline slog.Handler = newPipelineHandler(logger, output, json)
+	if attrs := logger.defaultAttrs(); len(attrs) > 0 {
+		pipeline = pipeline.WithAttrs(attrs)
+	}
+	logger.slogger.Store(slog.New(pipeline))
+}
+
+// SetDefaultFields sets fields that are added to every entry of the logger, including
+// those logged through GetSlogLogger.
+func (logger *Logger) SetDefaultFields(fields Fields) {
+	logger.mu.Lock()
+	logger.defaultFields = make(Fields, len(fields))
+	for k, v := range fields {
+		logger.defaultFields[k] = v
+	}
+	logger.mu.Unlock()
+
+	logger.rebuildHandler()
+}
+
+// rebuildHandler rebuilds the slog.Logger around the current output handler after the
+// sinks, default fields or level rules changed.
+func (logger *Logger) rebuildHandler() {
+	logger.handlerMu.Lock()
+	defer logger.handlerMu.Unlock()
+
+	logger.setHandler(logger.handler)
+}
+
+// defaultAttrs returns the default fields as attributes sorted by name.
+func (logger *Logger) defaultAttrs() []slog.Attr {
+	logger.mu.RLock()
+	defer logger.mu.RUnlock()
+
+	attrs := make([]slog.Attr, 0, len(logger.defaultFields))
+	for k, v := range logger.defaultFields {
+		attrs = append(attrs, slog.Any(k, v))
 	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+
+	return attrs
+}
+
+// GetLevel returns the logging Level of the logger, it is safe to call while the
+// level is changed by SetLevel.
+func (logger *Logger) GetLevel() Level {
+	return Level(atomic.LoadUint32((*uint32)(&logger.Level)))
 }
 
-// IsLevelEnabled checks if the given Level is enabled for logging.
+// IsLevelEnabled checks if the given Level is enabled for logging on any output.
 func (logger *Logger) IsLevelEnabled(level Level) bool {
-	return level <= logger.Level
+	return level <= logger.GetLevel() || level <= Level(logger.sinkLevel.Load())
 }
 
 // GetSlogLogger returns the underlying slog.Logger instance.
 // This enables advanced slog operations and direct access to slog APIs.
 func (logger *Logger) GetSlogLogger() *slog.Logger {
-	return logger.slogger
+	return logger.slogger.Load()
 }
 
 // WithField creates an entry with a single field.
@@ -138,43 +262,62 @@ func (logger *Logger) WithError(err error) *Entry {
 
 // log is the internal logging method
 func (logger *Logger) log(level Level, args ...any) {
-	if !logger.IsLevelEnabled(level) {
+	if !logger.verbose(level) {
+		return
+	}
+
+	ctx, ok := logger.levelContext(backgroundContext, "", level)
+	if !ok || !logger.sampleArgs(level, args) {
 		return
 	}
 
 	// Fast path - direct slog call without Entry allocation
 	msg := fmt.Sprint(args...)
-	logger.slogger.Log(backgroundContext, level.toSlogLevel(), msg)
+	logger.slogger.Load().Log(ctx, level.toSlogLevel(), msg)
 
 	// Handle Fatal and Panic levels
 	if level == FatalLevel {
+		logger.Flush()
 		os.Exit(1)
 	} else if level == PanicLevel {
+		logger.Flush()
 		panic(msg)
 	}
 }
 
 // logf is the internal formatted logging method
 func (logger *Logger) logf(level Level, format string, args ...any) {
-	if !logger.IsLevelEnabled(level) {
+	if !logger.verbose(level) {
+		return
+	}
+
+	ctx, ok := logger.levelContext(backgroundContext, "", level)
+	if !ok || !logger.sample(level, format) {
 		return
 	}
 
 	// Fast path - direct slog call without Entry allocation
 	msg := fmt.Sprintf(format, args...)
-	logger.slogger.Log(backgroundContext, level.toSlogLevel(), msg)
+	logger.slogger.Load().Log(ctx, level.toSlogLevel(), msg)
 
 	// Handle Fatal and Panic levels
 	if level == FatalLevel {
+		logger.Flush()
 		os.Exit(1)
 	} else if level == PanicLevel {
+		logger.Flush()
 		panic(msg)
 	}
 }
 
 // logln is the internal line logging method
 func (logger *Logger) logln(level Level, args ...any) {
-	if !logger.IsLevelEnabled(level) {
+	if !logger.verbose(level) {
+		return
+	}
+
+	ctx, ok := logger.levelContext(backgroundContext, "", level)
+	if !ok || !logger.sampleArgs(level, args) {
 		return
 	}
 
@@ -184,12 +327,14 @@ func (logger *Logger) logln(level Level, args ...any) {
 	if len(msg) > 0 && msg[len(msg)-1] == '\n' {
 		msg = msg[:len(msg)-1]
 	}
-	logger.slogger.Log(backgroundContext, level.toSlogLevel(), msg)
+	logger.slogger.Load().Log(ctx, level.toSlogLevel(), msg)
 
 	// Handle Fatal and Panic levels
 	if level == FatalLevel {
+		logger.Flush()
 		os.Exit(1)
 	} else if level == PanicLevel {
+		logger.Flush()
 		panic(msg)
 	}
 }
@@ -342,3 +487,13 @@ func (logger *Logger) Writer() *io.PipeWriter {
 func (logger *Logger) WriterLevel(level Level) *io.PipeWriter {
 	return NewEntry(logger).WriterLevel(level)
 }
+
+// WriterCloser returns a line buffered io.WriteCloser that writes to the logger at the info log Level.
+func (logger *Logger) WriterCloser() io.WriteCloser {
+	return logger.WriterLevelCloser(InfoLevel)
+}
+
+// WriterLevelCloser returns a line buffered io.WriteCloser that writes to the logger at the given log Level.
+func (logger *Logger) WriterLevelCloser(level Level) io.WriteCloser {
+	return NewEntry(logger).WriterLevelCloser(level)
+}
@@ -33,6 +33,22 @@ func TestNewWithHandler(t *testing.T) {
 	}
 }
 
+func TestNewWithHandlerSetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+
+	// a handler the logger cannot recreate with another level
+	logger := NewWithHandler(struct{ slog.Handler }{handler})
+
+	logger.Debug("before")
+	logger.SetLevel(DebugLevel)
+	logger.Debug("after")
+
+	if strings.Contains(buf.String(), "before") || !strings.Contains(buf.String(), "after") {
+		t.Errorf("output = %q, want only the entry logged after SetLevel", buf.String())
+	}
+}
+
 func TestLoggerSetLevel(t *testing.T) {
 	logger := New()
 	logger.SetLevel(DebugLevel)
@@ -0,0 +1,328 @@
+package logrus
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// LoggerNameKey is the field name holding the name of entries created with Named.
+var LoggerNameKey = "logger"
+
+// levelRules holds the level overrides of named loggers, it is replaced as a whole
+// when a rule changes.
+type levelRules struct {
+	// names maps logger names, and "*" for any name, to their level
+	names map[string]Level
+}
+
+// effectiveLevelKey is the context key carrying the level an entry was checked against.
+type effectiveLevelKey struct{}
+
+// Named returns an entry for the named component, the name is added as the logger field
+// and its level is taken from the longest matching rule set with SetLevelRules or
+// SetNamedLevel, falling back to the logger's level.
+func (logger *Logger) Named(name string) *Entry {
+	return NewEntry(logger).Named(name)
+}
+
+// Named returns an entry for a sub component, its name is appended to the entry's name
+// separated by a dot, for example "federation" and "broker" give "federation.broker".
+func (entry *Entry) Named(name string) *Entry {
+	if entry.name != "" {
+		name = entry.name + "." + name
+	}
+
+	named := entry.WithField(LoggerNameKey, name)
+	named.name = name
+	// names are usually known already, a Load avoids writing the map on every call
+	if _, known := entry.logger.names.Load(name); !known {
+		entry.logger.names.LoadOrStore(name, struct{}{})
+	}
+
+	return named
+}
+
+// Named returns an entry for the named component using the standard logger.
+func Named(name string) *Entry {
+	return standardLogger.Named(name)
+}
+
+// SetLevelRules replaces the levels of named loggers with rules such as
+// "federation=debug,federation.broker=trace,*=info". A rule applies to the name and all
+// names below it, the longest match wins and "*" matches all named loggers. Changes
+// apply to existing named loggers immediately.
+func (logger *Logger) SetLevelRules(rules string) error {
+	names := make(map[string]Level)
+
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		name, lvl, ok := strings.Cut(rule, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return fmt.Errorf("invalid level rule %q", rule)
+		}
+
+		level, err := ParseLevel(strings.TrimSpace(lvl))
+		if err != nil {
+			return fmt.Errorf("invalid level rule %q: %w", rule, err)
+		}
+
+		names[name] = level
+	}
+
+	logger.setLevelRules(names)
+
+	return nil
+}
+
+// SetLevelRules replaces the levels of named loggers of the standard logger.
+func SetLevelRules(rules string) error {
+	return standardLogger.SetLevelRules(rules)
+}
+
+// SetNamedLevel sets the level of name and the names below it.
+func (logger *Logger) SetNamedLevel(name string, level Level) {
+	logger.updateLevelRules(func(names map[string]Level) {
+		names[name] = level
+	})
+}
+
+// ResetNamedLevel removes the rule for name set with SetNamedLevel or SetLevelRules.
+func (logger *Logger) ResetNamedLevel(name string) {
+	logger.updateLevelRules(func(names map[string]Level) {
+		delete(names, name)
+	})
+}
+
+// NamedLevel returns the effective level of the named logger.
+func (logger *Logger) NamedLevel(name string) Level {
+	if rules := logger.levelRules.Load(); rules != nil {
+		if level, ok := rules.nameLevel(name); ok {
+			return level
+		}
+	}
+
+	return logger.GetLevel()
+}
+
+// NamedLoggers returns the names of all loggers created with Named, sorted.
+func (logger *Logger) NamedLoggers() []string {
+	var names []string
+	logger.names.Range(func(name, _ any) bool {
+		names = append(names, name.(string))
+		return true
+	})
+	sort.Strings(names)
+
+	return names
+}
+
+// namedRule returns the level of the rule set for exactly name.
+func (logger *Logger) namedRule(name string) (Level, bool) {
+	rules := logger.levelRules.Load()
+	if rules == nil {
+		return 0, false
+	}
+
+	level, ok := rules.names[name]
+
+	return level, ok
+}
+
+// updateLevelRules applies update to a copy of the current rules and installs the result,
+// concurrent updates such as those of the level HTTP handler and its reverts are not lost.
+func (logger *Logger) updateLevelRules(update func(names map[string]Level)) {
+	logger.mu.Lock()
+	names := make(map[string]Level)
+	if rules := logger.levelRules.Load(); rules != nil {
+		for name, level := range rules.names {
+			names[name] = level
+		}
+	}
+	update(names)
+	logger.storeLevelRules(names)
+	logger.mu.Unlock()
+
+	logger.applyLevelRules()
+}
+
+// setLevelRules installs names as the level rules of named loggers.
+func (logger *Logger) setLevelRules(names map[string]Level) {
+	logger.mu.Lock()
+	logger.storeLevelRules(names)
+	logger.mu.Unlock()
+
+	logger.applyLevelRules()
+}
+
+// storeLevelRules stores names as the level rules, the caller holds mu.
+func (logger *Logger) storeLevelRules(names map[string]Level) {
+	if len(names) == 0 {
+		logger.levelRules.Store(nil)
+	} else {
+		logger.levelRules.Store(&levelRules{names: names})
+	}
+}
+
+// hasLevelRules reports whether named logger or package level rules are configured.
+func (logger *Logger) hasLevelRules() bool {
+	return logger.levelRules.Load() != nil || logger.packageRules.Load() != nil
+}
+
+// applyLevelRules rebuilds the output handler so that it handles the most verbose
+// level of any rule.
+func (logger *Logger) applyLevelRules() {
+	logger.handlerMu.Lock()
+	defer logger.handlerMu.Unlock()
+
+	opts := logger.handlerOptions()
+
+	if formatter, ok := handlerFormatter(logger.handler); ok {
+		logger.setHandler(newFormatterHandler(formatter, logger.Out, opts))
+	} else {
+		logger.setHandler(logger.handler)
+	}
+}
+
+// handlerLevel is the level the output handler is created with, the most verbose of the
+// logger's level and its rules. The level of each entry is checked before it is logged.
+func (logger *Logger) handlerLevel() Level {
+	level := logger.GetLevel()
+	if rules := logger.levelRules.Load(); rules != nil {
+		for _, l := range rules.names {
+			level = max(level, l)
+		}
+	}
+	if rules := logger.packageRules.Load(); rules != nil {
+		for _, p := range rules.patterns {
+			level = max(level, p.level)
+		}
+	}
+
+	return level
+}
+
+// levelContext reports whether an entry of the named logger at level is enabled and returns
+// the context to log it with. The level comes from the rules of named loggers, then those
+// of the calling package and then the logger. When a rule matched the context carries
+// the effective level so that the output handler can apply it.
+func (logger *Logger) levelContext(ctx context.Context, name string, level Level) (context.Context, bool) {
+	if !logger.verbose(level) {
+		return ctx, false
+	}
+
+	names := logger.levelRules.Load()
+	packages := logger.packageRules.Load()
+	if names == nil && packages == nil {
+		return ctx, logger.IsLevelEnabled(level)
+	}
+
+	var effective Level
+	var matched bool
+	if names != nil && name != "" {
+		effective, matched = names.nameLevel(name)
+	}
+	if !matched && packages != nil {
+		// skips runtime.Callers, callerLevel and levelContext
+		effective, matched = packages.callerLevel(3)
+	}
+	if !matched {
+		return ctx, logger.IsLevelEnabled(level)
+	}
+
+	return logger.effectiveLevelContext(ctx, effective, level)
+}
+
+// callerLevelContext is like levelContext for entries without a name whose caller is
+// already known, such as those of the standard library logger adapter.
+func (logger *Logger) callerLevelContext(ctx context.Context, pc uintptr, level Level) (context.Context, bool) {
+	packages := logger.packageRules.Load()
+	if packages == nil || pc == 0 {
+		return ctx, logger.IsLevelEnabled(level)
+	}
+
+	caller := packages.pcLevel(pc)
+	if caller.internal || !caller.matched {
+		return ctx, logger.IsLevelEnabled(level)
+	}
+
+	return logger.effectiveLevelContext(ctx, caller.level, level)
+}
+
+// effectiveLevelContext reports whether an entry at level is enabled when a rule set
+// the effective level and returns ctx carrying it.
+func (logger *Logger) effectiveLevelContext(ctx context.Context, effective Level, level Level) (context.Context, bool) {
+	if level > effective && level > Level(logger.sinkLevel.Load()) {
+		return ctx, false
+	}
+
+	return context.WithValue(ctx, effectiveLevelKey{}, effective), true
+}
+
+// levelContext reports whether the entry is enabled at level, see Logger.levelContext.
+func (entry *Entry) levelContext(level Level) (context.Context, bool) {
+	return entry.logger.levelContext(entry.Context, entry.name, level)
+}
+
+// verbose reports whether level is enabled by the level, a sink or a rule. It is small
+// enough to be inlined so that entries at disabled levels are dropped cheaply.
+func (logger *Logger) verbose(level Level) bool {
+	return level <= Level(logger.verboseLevel.Load())
+}
+
+// nameLevel returns the level of the longest rule matching name.
+func (r *levelRules) nameLevel(name string) (Level, bool) {
+	for prefix := name; prefix != ""; {
+		if level, ok := r.names[prefix]; ok {
+			return level, true
+		}
+
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+
+	level, ok := r.names["*"]
+
+	return level, ok
+}
+
+// levelGate limits the default output to the level entries were checked against, or the
+// logger's level for records without one such as those logged through GetSlogLogger.
+// It is used when the output handler was lowered to the most verbose level rule.
+type levelGate struct {
+	logger *Logger
+	next   slog.Handler
+}
+
+func (h *levelGate) Enabled(ctx context.Context, level slog.Level) bool {
+	effective := h.logger.GetLevel()
+	if ctx != nil {
+		if l, ok := ctx.Value(effectiveLevelKey{}).(Level); ok {
+			effective = l
+		}
+	}
+
+	return levelFromSlog(level) <= effective && h.next.Enabled(ctx, level)
+}
+
+func (h *levelGate) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *levelGate) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelGate{logger: h.logger, next: h.next.WithAttrs(attrs)}
+}
+
+func (h *levelGate) WithGroup(name string) slog.Handler {
+	return &levelGate{logger: h.logger, next: h.next.WithGroup(name)}
+}
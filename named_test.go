@@ -0,0 +1,107 @@
+package logrus
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNamedLevelRules(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := New()
+	logger.SetOutput(&buf)
+
+	if err := logger.SetLevelRules("federation=debug, federation.broker=trace, noisy=error"); err != nil {
+		t.Fatalf("SetLevelRules() error = %v", err)
+	}
+
+	federation := logger.Named("federation")
+	broker := federation.Named("broker")
+	noisy := logger.Named("noisy").WithField("k", "v")
+	other := logger.Named("other")
+
+	federation.Debug("federation debug")
+	federation.Trace("federation trace")
+	broker.Trace("broker trace")
+	noisy.Warn("noisy warn")
+	noisy.Error("noisy error")
+	other.Info("other info")
+	other.Debug("other debug")
+	logger.Debug("unnamed debug")
+	logger.GetSlogLogger().Debug("slog debug")
+
+	output := buf.String()
+	for _, want := range []string{
+		"msg=\"federation debug\" logger=federation",
+		"msg=\"broker trace\" logger=federation.broker",
+		"msg=\"noisy error\"",
+		"msg=\"other info\" logger=other",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	for _, unwanted := range []string{"federation trace", "noisy warn", "other debug", "unnamed debug", "slog debug"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("output contains %q:\n%s", unwanted, output)
+		}
+	}
+
+	// rule changes apply to existing named loggers
+	buf.Reset()
+	logger.SetNamedLevel("*", DebugLevel)
+	logger.ResetNamedLevel("federation.broker")
+	other.Debug("other debug")
+	broker.Trace("broker trace")
+	broker.Debug("broker debug")
+
+	output = buf.String()
+	if !strings.Contains(output, "other debug") || !strings.Contains(output, "broker debug") || strings.Contains(output, "broker trace") {
+		t.Errorf("output after rule changes:\n%s", output)
+	}
+
+	if level := logger.NamedLevel("federation.broker.conn"); level != DebugLevel {
+		t.Errorf("NamedLevel() = %v, want %v", level, DebugLevel)
+	}
+	if level := logger.NamedLevel("federationx"); level != DebugLevel {
+		t.Errorf("NamedLevel(federationx) = %v, want the * rule %v", level, DebugLevel)
+	}
+
+	logger.SetLevelRules("")
+	buf.Reset()
+	other.Debug("without rules")
+	logger.Info("without rules")
+	if strings.Count(buf.String(), "without rules") != 1 {
+		t.Errorf("output without rules:\n%s", buf.String())
+	}
+}
+
+func TestSetLevelRulesInvalid(t *testing.T) {
+	logger := New()
+
+	for _, rules := range []string{"federation", "=debug", "federation=loud"} {
+		if err := logger.SetLevelRules(rules); err == nil {
+			t.Errorf("SetLevelRules(%q) error = nil, want an error", rules)
+		}
+	}
+}
+
+func TestNamedLevelWithSinks(t *testing.T) {
+	var primary, sink bytes.Buffer
+
+	logger := New()
+	logger.SetOutput(&primary)
+	logger.AddSink(Sink{Name: "debug", Out: &sink, Level: DebugLevel})
+	logger.SetNamedLevel("quiet", ErrorLevel)
+
+	logger.Named("quiet").Info("quiet info")
+	logger.Debug("unnamed debug")
+
+	if strings.Contains(primary.String(), "quiet info") || strings.Contains(primary.String(), "unnamed debug") {
+		t.Errorf("primary output:\n%s", primary.String())
+	}
+	if !strings.Contains(sink.String(), "quiet info") || !strings.Contains(sink.String(), "unnamed debug") {
+		t.Errorf("sink output:\n%s", sink.String())
+	}
+}
@@ -0,0 +1,172 @@
+package logrus
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// pipelineHandler sits in front of the output handler of a Logger and applies the
+// logger-wide processing that has to happen for every record, regardless of whether
+// it was produced by an Entry or directly through the slog.Logger from GetSlogLogger.
+type pipelineHandler struct {
+	logger *Logger
+	base   slog.Handler
+
+	// json is set when the logger writes JSON, which changes how some values are rendered
+	json bool
+
+	// ops are the attributes and groups added with WithAttrs and WithGroup, they are
+	// kept as given so that they are redacted with the redactor in use when logging
+	ops []pipelineOp
+
+	// next is base with ops applied for the redactor it was built with
+	next atomic.Pointer[pipelineNext]
+}
+
+// pipelineOp is a group, or attributes when group is empty, added to the handler.
+type pipelineOp struct {
+	group string
+	attrs []slog.Attr
+}
+
+// pipelineNext is the output handler with the operations applied using redactor.
+type pipelineNext struct {
+	redactor *redactor
+	handler  slog.Handler
+}
+
+func newPipelineHandler(logger *Logger, next slog.Handler, json bool) *pipelineHandler {
+	return &pipelineHandler{logger: logger, base: next, json: json}
+}
+
+// Enabled reports whether the output handler handles records at the given level, notices
+// are always handled.
+func (h *pipelineHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return isNotice(ctx) || h.base.Enabled(ctx, level)
+}
+
+// outputHandler returns the output handler with the attributes and groups of h, the
+// attributes are converted and redacted again when the redactor changed.
+func (h *pipelineHandler) outputHandler(redactor *redactor) slog.Handler {
+	if len(h.ops) == 0 {
+		return h.base
+	}
+
+	if next := h.next.Load(); next != nil && next.redactor == redactor {
+		return next.handler
+	}
+
+	c := attrConverter{json: h.json, redactor: redactor}
+	handler := h.base
+	for _, op := range h.ops {
+		if op.group != "" {
+			handler = handler.WithGroup(op.group)
+			continue
+		}
+
+		converted := make([]slog.Attr, 0, len(op.attrs))
+		for _, a := range op.attrs {
+			converted = c.appendAttr(converted, a, 0)
+		}
+		handler = handler.WithAttrs(converted)
+	}
+	h.next.Store(&pipelineNext{redactor: redactor, handler: handler})
+
+	return handler
+}
+
+// Handle runs the logger's processing steps on r and passes it to the output handler.
+func (h *pipelineHandler) Handle(ctx context.Context, r slog.Record) error {
+	var extracted Fields
+	if ctx != nil && ctx != backgroundContext {
+		extracted = h.logger.extractContextFields(ctx)
+	}
+
+	redactor := h.logger.currentRedactor()
+
+	rewrite := len(extracted) > 0 || redactor != nil
+	if !rewrite {
+		r.Attrs(func(a slog.Attr) bool {
+			rewrite = needsConversion(a)
+			return !rewrite
+		})
+	}
+	if rewrite {
+		r = h.rewrite(r, extracted, redactor)
+	}
+
+	return h.output(ctx, r)
+}
+
+// output passes r to the output handler, through deduplication when enabled.
+func (h *pipelineHandler) output(ctx context.Context, r slog.Record) error {
+	if d := h.logger.deduper.Load(); d != nil {
+		return d.handle(ctx, h, r)
+	}
+
+	return h.emit(ctx, r)
+}
+
+// emit passes r to the output handler, through the asynchronous queue when enabled.
+func (h *pipelineHandler) emit(ctx context.Context, r slog.Record) error {
+	next := h.outputHandler(h.logger.currentRedactor())
+	if a := h.logger.async.Load(); a != nil {
+		a.enqueue(ctx, r, next)
+		return nil
+	}
+
+	return next.Handle(ctx, r)
+}
+
+// rewrite returns a copy of r with converted attributes and the extracted fields
+// added, fields already present on the record take precedence over extracted ones.
+func (h *pipelineHandler) rewrite(r slog.Record, extracted Fields, redactor *redactor) slog.Record {
+	c := attrConverter{json: h.json, redactor: redactor}
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs()+len(extracted))
+	r.Attrs(func(a slog.Attr) bool {
+		delete(extracted, a.Key)
+		attrs = c.appendAttr(attrs, a, 0)
+		return true
+	})
+	for k, v := range extracted {
+		attrs = c.appendAttr(attrs, slog.Any(k, v), 0)
+	}
+
+	msg := r.Message
+	if redactor != nil {
+		msg = redactor.redactString(msg)
+	}
+
+	nr := slog.NewRecord(r.Time, r.Level, msg, r.PC)
+	nr.AddAttrs(attrs...)
+
+	return nr
+}
+
+// WithAttrs returns a pipelineHandler whose output handler has the given attributes.
+func (h *pipelineHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	return h.with(pipelineOp{attrs: attrs})
+}
+
+// WithGroup returns a pipelineHandler whose output handler has the given group.
+func (h *pipelineHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return h.with(pipelineOp{group: name})
+}
+
+// with returns a copy of h with op added.
+func (h *pipelineHandler) with(op pipelineOp) *pipelineHandler {
+	ops := make([]pipelineOp, len(h.ops), len(h.ops)+1)
+	copy(ops, h.ops)
+
+	return &pipelineHandler{logger: h.logger, base: h.base, json: h.json, ops: append(ops, op)}
+}
@@ -0,0 +1,150 @@
+package logrus
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// packageRules holds the levels of Go packages and the levels found for caller pcs.
+type packageRules struct {
+	// patterns are sorted longest first so that the first match is the most specific
+	patterns []packagePattern
+
+	// callers caches the callerLevel of each pc
+	callers sync.Map
+}
+
+// packagePattern is a package import path, ending in "/..." to include the packages below it.
+type packagePattern struct {
+	path      string
+	recursive bool
+	level     Level
+}
+
+// callerLevel is the cached level for a pc, internal pcs belong to this package.
+type callerLevel struct {
+	internal bool
+	matched  bool
+	level    Level
+}
+
+// SetPackageLevels sets the level of entries logged from Go packages, chosen by the import
+// path of the calling function, with rules such as "github.com/foo/bar/...=warn". A path
+// ending in "/..." includes the packages below it and the longest matching rule wins.
+// Rules of named loggers take precedence, passing an empty string removes all rules.
+func (logger *Logger) SetPackageLevels(rules string) error {
+	var patterns []packagePattern
+
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		path, lvl, ok := strings.Cut(rule, "=")
+		path = strings.TrimSpace(path)
+		if !ok || path == "" || path == "/..." {
+			return fmt.Errorf("invalid package level rule %q", rule)
+		}
+
+		level, err := ParseLevel(strings.TrimSpace(lvl))
+		if err != nil {
+			return fmt.Errorf("invalid package level rule %q: %w", rule, err)
+		}
+
+		pattern := packagePattern{path: path, level: level}
+		if trimmed, found := strings.CutSuffix(path, "/..."); found {
+			pattern.path = trimmed
+			pattern.recursive = true
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	sort.SliceStable(patterns, func(i, j int) bool {
+		return len(patterns[i].path) > len(patterns[j].path)
+	})
+
+	if len(patterns) == 0 {
+		logger.packageRules.Store(nil)
+	} else {
+		logger.packageRules.Store(&packageRules{patterns: patterns})
+	}
+	logger.applyLevelRules()
+
+	return nil
+}
+
+// SetPackageLevels sets the level of entries logged through the standard logger from Go packages.
+func SetPackageLevels(rules string) error {
+	return standardLogger.SetPackageLevels(rules)
+}
+
+// callerLevel returns the level of the package of the first caller outside this
+// package, skip is the number of frames to skip as for runtime.Callers.
+func (r *packageRules) callerLevel(skip int) (Level, bool) {
+	var pcs [16]uintptr
+	n := runtime.Callers(skip+1, pcs[:])
+
+	for _, pc := range pcs[:n] {
+		if caller := r.pcLevel(pc); !caller.internal {
+			return caller.level, caller.matched
+		}
+	}
+
+	return 0, false
+}
+
+// pcLevel returns the cached level for pc, resolving it on first use.
+func (r *packageRules) pcLevel(pc uintptr) callerLevel {
+	if cached, ok := r.callers.Load(pc); ok {
+		return cached.(callerLevel)
+	}
+
+	caller := r.resolve(pc)
+	r.callers.Store(pc, caller)
+
+	return caller
+}
+
+// resolve finds the level for pc, which can cover several functions when calls were inlined.
+func (r *packageRules) resolve(pc uintptr) callerLevel {
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		frame, more := frames.Next()
+
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			level, ok := r.packageLevel(functionPackage(frame.Function))
+			return callerLevel{matched: ok, level: level}
+		}
+
+		if !more {
+			return callerLevel{internal: true}
+		}
+	}
+}
+
+// packageLevel returns the level of the most specific rule matching the import path.
+func (r *packageRules) packageLevel(path string) (Level, bool) {
+	for _, p := range r.patterns {
+		if path == p.path || (p.recursive && strings.HasPrefix(path, p.path+"/")) {
+			return p.level, true
+		}
+	}
+
+	return 0, false
+}
+
+// functionPackage returns the import path of the package of a function name as reported
+// by runtime.Frame, for example "github.com/foo/bar" for "github.com/foo/bar.(*T).Method".
+func functionPackage(function string) string {
+	slash := strings.LastIndexByte(function, '/')
+	dot := strings.IndexByte(function[slash+1:], '.')
+	if dot < 0 {
+		return function
+	}
+
+	return function[:slash+1+dot]
+}
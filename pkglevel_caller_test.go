@@ -0,0 +1,99 @@
+package logrus_test
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	logrus "github.com/choria-io/slogrus"
+)
+
+func TestPackageLevels(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+
+	// this test is a caller from the external test package
+	if err := logger.SetPackageLevels("github.com/choria-io/slogrus_test=warn, github.com/other/...=trace"); err != nil {
+		t.Fatalf("SetPackageLevels() error = %v", err)
+	}
+
+	logger.Info("logger info")
+	logger.Warnf("logger %s", "warn")
+	logger.WithField("k", "v").Info("entry info")
+	logger.WithField("k", "v").Errorln("entry error")
+	logger.Named("named").Info("named info")
+
+	output := buf.String()
+	for _, want := range []string{"logger warn", "entry error"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	for _, unwanted := range []string{"logger info", "entry info", "named info"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("output contains %q:\n%s", unwanted, output)
+		}
+	}
+
+	// rules of named loggers take precedence
+	buf.Reset()
+	logger.SetNamedLevel("named", logrus.DebugLevel)
+	logger.Named("named").Debug("named debug")
+	if !strings.Contains(buf.String(), "named debug") {
+		t.Errorf("named rule was not applied:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	logger.SetPackageLevels("github.com/choria-io/...=trace")
+	logger.Trace("recursive trace")
+	logger.GetSlogLogger().Debug("slog debug")
+	if !strings.Contains(buf.String(), "recursive trace") || strings.Contains(buf.String(), "slog debug") {
+		t.Errorf("output with a recursive rule:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	logger.SetPackageLevels("")
+	logger.Trace("without rules")
+	if buf.Len() != 0 {
+		t.Errorf("output without rules:\n%s", buf.String())
+	}
+}
+
+func TestPackageLevelsStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	if err := logger.SetPackageLevels("github.com/choria-io/slogrus_test=warn"); err != nil {
+		t.Fatalf("SetPackageLevels() error = %v", err)
+	}
+
+	std := logger.StdLogger(logrus.InfoLevel)
+	std.Print("std info")
+	logger.StdLogger(logrus.ErrorLevel).Print("std error")
+
+	restore := logger.RedirectStdLog(logrus.InfoLevel)
+	log.Print("redirected info")
+	restore()
+
+	output := buf.String()
+	if !strings.Contains(output, "std error") {
+		t.Errorf("output missing %q:\n%s", "std error", output)
+	}
+	for _, unwanted := range []string{"std info", "redirected info"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("output contains %q:\n%s", unwanted, output)
+		}
+	}
+
+	// without a matching rule the level of the logger applies
+	buf.Reset()
+	logger.SetPackageLevels("github.com/other/...=warn")
+	std.Print("std info")
+	if !strings.Contains(buf.String(), "std info") {
+		t.Errorf("output without a matching rule:\n%s", buf.String())
+	}
+}
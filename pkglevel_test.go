@@ -0,0 +1,53 @@
+package logrus
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPackageLevelsCache(t *testing.T) {
+	logger := New()
+	logger.SetOutput(&bytes.Buffer{})
+	// the first caller outside this package is the testing package
+	logger.SetPackageLevels("testing=debug")
+
+	for i := 0; i < 3; i++ {
+		logger.Debug("cached")
+	}
+
+	cached := 0
+	logger.packageRules.Load().callers.Range(func(_, _ any) bool {
+		cached++
+		return true
+	})
+	if cached == 0 || cached > 8 {
+		t.Errorf("cached %d pcs after logging from one call site", cached)
+	}
+}
+
+func TestSetPackageLevelsInvalid(t *testing.T) {
+	logger := New()
+
+	for _, rules := range []string{"github.com/foo", "=warn", "/...=warn", "github.com/foo=loud"} {
+		if err := logger.SetPackageLevels(rules); err == nil {
+			t.Errorf("SetPackageLevels(%q) error = nil, want an error", rules)
+		}
+	}
+}
+
+func TestFunctionPackage(t *testing.T) {
+	tests := map[string]string{
+		"github.com/foo/bar.Func":             "github.com/foo/bar",
+		"github.com/foo/bar.(*T).Method":      "github.com/foo/bar",
+		"github.com/foo/bar.Func.func1":       "github.com/foo/bar",
+		"github.com/foo/bar.v2/baz.(*T).Call": "github.com/foo/bar.v2/baz",
+		"main.main":                           "main",
+		"net/http.(*Server).Serve":            "net/http",
+	}
+
+	for function, want := range tests {
+		if got := functionPackage(function); got != want {
+			t.Errorf("functionPackage(%q) = %q, want %q", function, got, want)
+		}
+	}
+}
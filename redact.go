@@ -0,0 +1,115 @@
+package logrus
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactedValue replaces redacted values unless Redaction.Replacement is set.
+const DefaultRedactedValue = "[REDACTED]"
+
+// Redactor is implemented by values that control how they are logged, Redact returns
+// the value to log in their place, for example a masked version of a credential.
+// It is honored on every logger, also when no Redaction is configured.
+type Redactor interface {
+	Redact() any
+}
+
+// Redaction describes which fields and parts of messages are redacted before output.
+type Redaction struct {
+	// Keys are glob patterns, as understood by path.Match, matched against field names
+	// ignoring case, for example "*password*" or "authorization"
+	Keys []string
+
+	// Patterns are replaced in messages, string field values and error messages
+	Patterns []*regexp.Regexp
+
+	// Replacement replaces redacted values, DefaultRedactedValue when empty
+	Replacement string
+}
+
+// redactor is the validated form of a Redaction.
+type redactor struct {
+	keys        []string
+	patterns    []*regexp.Regexp
+	replacement string
+}
+
+// SetRedaction configures redaction of secrets and personal data for all records logged
+// afterwards, including those logged through GetSlogLogger and the writers. Passing nil
+// disables redaction.
+func (logger *Logger) SetRedaction(redaction *Redaction) error {
+	var r *redactor
+
+	if redaction != nil {
+		r = &redactor{
+			patterns:    redaction.Patterns,
+			replacement: redaction.Replacement,
+		}
+		if r.replacement == "" {
+			r.replacement = DefaultRedactedValue
+		}
+
+		for _, key := range redaction.Keys {
+			key = strings.ToLower(key)
+			if _, err := path.Match(key, ""); err != nil {
+				return fmt.Errorf("invalid redaction key pattern %q: %w", key, err)
+			}
+			r.keys = append(r.keys, key)
+		}
+	}
+
+	logger.redactor.Store(r)
+
+	return nil
+}
+
+// SetRedaction configures redaction for the standard logger.
+func SetRedaction(redaction *Redaction) error {
+	return standardLogger.SetRedaction(redaction)
+}
+
+// currentRedactor returns the configured redactor or nil when redaction is disabled.
+func (logger *Logger) currentRedactor() *redactor {
+	return logger.redactor.Load()
+}
+
+// redactsKey reports whether the field named key should be redacted.
+func (r *redactor) redactsKey(key string) bool {
+	if len(r.keys) == 0 {
+		return false
+	}
+
+	key = strings.ToLower(key)
+	for _, pattern := range r.keys {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactString replaces all matches of the patterns in s.
+func (r *redactor) redactString(s string) string {
+	for _, pattern := range r.patterns {
+		s = pattern.ReplaceAllString(s, r.replacement)
+	}
+	return s
+}
+
+// redactValue replaces values implementing Redactor with the value they return.
+func redactValue(v slog.Value) slog.Value {
+	if v.Kind() != slog.KindAny && v.Kind() != slog.KindLogValuer {
+		return v
+	}
+
+	if r, ok := v.Any().(Redactor); ok {
+		return slog.AnyValue(r.Redact())
+	}
+
+	return v
+}
@@ -0,0 +1,152 @@
+package logrus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type apiToken string
+
+func (t apiToken) Redact() any {
+	if len(t) < 4 {
+		return DefaultRedactedValue
+	}
+	return "****" + string(t[len(t)-4:])
+}
+
+func newRedactingLogger(t *testing.T, buf *bytes.Buffer) *Logger {
+	t.Helper()
+
+	logger := NewTextLogger(buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	err := logger.SetRedaction(&Redaction{
+		Keys:     []string{"*password*", "Authorization"},
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`[a-z0-9.]+@[a-z0-9.]+`)},
+	})
+	if err != nil {
+		t.Fatalf("SetRedaction() returned error: %v", err)
+	}
+
+	return logger
+}
+
+func TestRedactionEntry(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newRedactingLogger(t, &buf)
+
+	logger.WithFields(Fields{
+		"db_password":   "hunter2",
+		"authorization": "Bearer abc",
+		"user":          Fields{"email": "bob@example.com", "Password": "secret"},
+		"token":         apiToken("abcdef123456"),
+	}).WithError(errors.New("invalid login for bob@example.com")).Info("login by bob@example.com")
+
+	output := buf.String()
+	for _, secret := range []string{"hunter2", "Bearer abc", "bob@example.com", "secret", "abcdef12"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %q to be redacted: %s", secret, output)
+		}
+	}
+	for _, want := range []string{
+		`msg="login by [REDACTED]"`,
+		"db_password=[REDACTED]",
+		"authorization=[REDACTED]",
+		"user.email=[REDACTED]",
+		"user.Password=[REDACTED]",
+		"token=****3456",
+		`error="invalid login for [REDACTED]"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output: %s", want, output)
+		}
+	}
+}
+
+func TestRedactionSlogAndWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newRedactingLogger(t, &buf)
+
+	logger.GetSlogLogger().With("password", "from-with").Info("slog call", "password", "from-record")
+
+	writer := logger.WriterCloser()
+	fmt.Fprintln(writer, "mail sent to alice@example.com")
+	writer.Close()
+
+	output := buf.String()
+	for _, secret := range []string{"from-with", "from-record", "alice@example.com"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %q to be redacted: %s", secret, output)
+		}
+	}
+}
+
+func TestRedactorWithoutRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, nil)
+
+	logger.WithField("token", apiToken("abcdef123456")).Info("token")
+
+	if !strings.Contains(buf.String(), "token=****3456") {
+		t.Errorf("Expected Redactor values to be honored without a Redaction: %s", buf.String())
+	}
+}
+
+func TestSetRedactionInvalidKey(t *testing.T) {
+	if err := New().SetRedaction(&Redaction{Keys: []string{"[invalid"}}); err == nil {
+		t.Error("Expected an error for an invalid key pattern")
+	}
+}
+
+func TestRedactionAfterWith(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	// attributes added before redaction was configured are redacted too
+	logger.SetDefaultFields(Fields{"password": "hunter2"})
+	slogger := logger.GetSlogLogger().With("api_password", "letmein")
+
+	err := logger.SetRedaction(&Redaction{Keys: []string{"*password*"}})
+	if err != nil {
+		t.Fatalf("SetRedaction() returned error: %v", err)
+	}
+
+	logger.Info("default fields")
+	slogger.Info("slog with")
+
+	output := buf.String()
+	for _, secret := range []string{"hunter2", "letmein"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %q to be redacted: %s", secret, output)
+		}
+	}
+
+	// and they are no longer redacted once it is disabled
+	buf.Reset()
+	logger.SetRedaction(nil)
+	slogger.Info("slog with")
+	if !strings.Contains(buf.String(), "api_password=letmein") {
+		t.Errorf("Expected field without redaction: %s", buf.String())
+	}
+}
+
+func TestRedactionBeforeWith(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newRedactingLogger(t, &buf)
+
+	logger.SetDefaultFields(Fields{"password": "hunter2"})
+	logger.GetSlogLogger().With("api_password", "letmein").WithGroup("user").Info("slog with", "email", "bob@example.com")
+
+	output := buf.String()
+	for _, secret := range []string{"hunter2", "letmein", "bob@example.com"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %q to be redacted: %s", secret, output)
+		}
+	}
+	if !strings.Contains(output, "user.email=[REDACTED]") {
+		t.Errorf("Expected grouped field to be redacted: %s", output)
+	}
+}
@@ -0,0 +1,200 @@
+package logrus
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultReopenCheckInterval is how often a ReopenFile checks whether its file was
+// renamed or deleted when CheckInterval is not set.
+const DefaultReopenCheckInterval = time.Second
+
+// FileOwner is the user and group a ReopenFile changes its file to.
+type FileOwner struct {
+	UID int
+	GID int
+}
+
+// ReopenFile is an io.WriteCloser writing to Filename that can reopen the file, for use
+// with an external rotation tool such as logrotate. The file is reopened on Reopen, on
+// SIGHUP after ReopenOnSignal and when a write notices the file was renamed or deleted.
+// Writes keep going to the old file until the new one is open so nothing is lost. It is
+// safe for concurrent use and the file is opened on the first write.
+type ReopenFile struct {
+	// Filename is the file to write to
+	Filename string
+
+	// Mode is the permission of the file, 0644 when 0
+	Mode os.FileMode
+
+	// Owner changes the owner of the file when set
+	Owner *FileOwner
+
+	// CheckInterval is how often writes check that Filename is still the open file,
+	// DefaultReopenCheckInterval when 0 and never when negative
+	CheckInterval time.Duration
+
+	mu      sync.Mutex
+	file    *os.File
+	info    os.FileInfo
+	checked time.Time
+
+	stopSignals func()
+}
+
+// Write writes p to the file, reopening it first when it was renamed or deleted.
+func (f *ReopenFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		err := f.reopen()
+		if err != nil {
+			return 0, err
+		}
+	} else if f.moved() {
+		// keep writing to the open file if the new one cannot be opened
+		f.reopen()
+	}
+
+	return f.file.Write(p)
+}
+
+// Reopen opens Filename again and closes the previously open file.
+func (f *ReopenFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.reopen()
+}
+
+// ReopenOnSignal reopens the file whenever the process receives one of sigs, SIGHUP when
+// none are given. The returned function stops listening for the signals.
+func (f *ReopenFile) ReopenOnSignal(sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 && defaultReopenSignal != nil {
+		sigs = []os.Signal{defaultReopenSignal}
+	}
+	if len(sigs) == 0 {
+		return func() {}
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, sigs...)
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				f.Reopen()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+
+	f.mu.Lock()
+	previous := f.stopSignals
+	f.stopSignals = stop
+	f.mu.Unlock()
+
+	if previous != nil {
+		previous()
+	}
+
+	return stop
+}
+
+// Close closes the file and stops listening for signals. Writing after Close opens
+// the file again.
+func (f *ReopenFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.stopSignals != nil {
+		f.stopSignals()
+		f.stopSignals = nil
+	}
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	f.info = nil
+
+	return err
+}
+
+// reopen opens Filename, applies the mode and owner and then replaces the open file.
+func (f *ReopenFile) reopen() error {
+	mode := f.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+
+	err := os.MkdirAll(filepath.Dir(f.Filename), 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, mode)
+	if err != nil {
+		return err
+	}
+
+	// the umask applies to new files, set the mode explicitly
+	err = file.Chmod(mode)
+	if err == nil && f.Owner != nil {
+		err = file.Chown(f.Owner.UID, f.Owner.GID)
+	}
+	var info os.FileInfo
+	if err == nil {
+		info, err = file.Stat()
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if f.file != nil {
+		f.file.Close()
+	}
+	f.file = file
+	f.info = info
+	f.checked = time.Now()
+
+	return nil
+}
+
+// moved reports whether Filename no longer refers to the open file, checking at most
+// once per CheckInterval.
+func (f *ReopenFile) moved() bool {
+	interval := f.CheckInterval
+	if interval == 0 {
+		interval = DefaultReopenCheckInterval
+	}
+	if interval < 0 || time.Since(f.checked) < interval {
+		return false
+	}
+	f.checked = time.Now()
+
+	info, err := os.Stat(f.Filename)
+	if err != nil {
+		return true
+	}
+
+	return !os.SameFile(info, f.info)
+}
@@ -0,0 +1,8 @@
+//go:build js
+
+package logrus
+
+import "os"
+
+// defaultReopenSignal is nil as there is no SIGHUP on this platform.
+var defaultReopenSignal os.Signal
@@ -0,0 +1,11 @@
+//go:build !js
+
+package logrus
+
+import (
+	"os"
+	"syscall"
+)
+
+// defaultReopenSignal is the signal ReopenOnSignal listens for when none are given.
+var defaultReopenSignal os.Signal = syscall.SIGHUP
@@ -0,0 +1,109 @@
+package logrus
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("ReadFile(%s) error = %v", name, err)
+	}
+	return string(data)
+}
+
+func TestReopenFileReopen(t *testing.T) {
+	dir := t.TempDir()
+	f := &ReopenFile{
+		Filename:      filepath.Join(dir, "app.log"),
+		Mode:          0600,
+		CheckInterval: -1,
+	}
+	defer f.Close()
+
+	f.Write([]byte("before\n"))
+	os.Rename(f.Filename, filepath.Join(dir, "app.log.1"))
+
+	// writes go to the renamed file until reopened
+	f.Write([]byte("rotating\n"))
+	if err := f.Reopen(); err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+	f.Write([]byte("after\n"))
+
+	if got := readFile(t, filepath.Join(dir, "app.log.1")); got != "before\nrotating\n" {
+		t.Errorf("rotated file = %q", got)
+	}
+	if got := readFile(t, f.Filename); got != "after\n" {
+		t.Errorf("new file = %q", got)
+	}
+
+	info, _ := os.Stat(f.Filename)
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestReopenFileDetectsRotation(t *testing.T) {
+	dir := t.TempDir()
+	f := &ReopenFile{
+		Filename:      filepath.Join(dir, "app.log"),
+		CheckInterval: time.Nanosecond,
+	}
+	defer f.Close()
+
+	f.Write([]byte("first\n"))
+	if runtime.GOOS != "windows" {
+		// open files cannot be removed on windows
+		os.Remove(f.Filename)
+		f.Write([]byte("second\n"))
+
+		if got := readFile(t, f.Filename); got != "second\n" {
+			t.Errorf("file after delete = %q, want %q", got, "second\n")
+		}
+	}
+}
+
+func TestReopenFileOnSignal(t *testing.T) {
+	if runtime.GOOS == "windows" || defaultReopenSignal == nil {
+		t.Skip("SIGHUP cannot be sent on this platform")
+	}
+
+	dir := t.TempDir()
+	f := &ReopenFile{
+		Filename:      filepath.Join(dir, "app.log"),
+		CheckInterval: -1,
+	}
+	defer f.Close()
+
+	stop := f.ReopenOnSignal()
+	defer stop()
+
+	f.Write([]byte("before\n"))
+	os.Rename(f.Filename, filepath.Join(dir, "app.log.1"))
+
+	process, _ := os.FindProcess(os.Getpid())
+	process.Signal(defaultReopenSignal)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(f.Filename); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("file was not reopened after SIGHUP")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	f.Write([]byte("after\n"))
+	if got := readFile(t, f.Filename); got != "after\n" {
+		t.Errorf("new file = %q", got)
+	}
+}
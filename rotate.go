@@ -0,0 +1,347 @@
+package logrus
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotationInterval is the period after which a RotatingFile starts a new file.
+type RotationInterval int
+
+const (
+	// RotateNever disables time based rotation
+	RotateNever RotationInterval = iota
+	// RotateHourly rotates at the start of every hour
+	RotateHourly
+	// RotateDaily rotates at midnight
+	RotateDaily
+)
+
+// backupTimeFormat is the timestamp added to the names of rotated files, it sorts lexically.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// compressSuffix is added to the names of compressed backups.
+const compressSuffix = ".gz"
+
+// RotatingFile is an io.WriteCloser writing to Filename that moves the file aside and
+// starts a new one once it reaches MaxSize bytes or a rotation interval passes. Rotated
+// files are named after Filename with the rotation time before the extension, for example
+// app-2006-01-02T15-04-05.000.log. It is safe for concurrent use and can be passed to
+// Logger.SetOutput directly, the file is opened on the first write.
+type RotatingFile struct {
+	// Filename is the file to write to, missing directories are created
+	Filename string
+
+	// MaxSize is the size in bytes after which the file is rotated, 0 disables size rotation
+	MaxSize int64
+
+	// Interval rotates the file every hour or day in addition to MaxSize
+	Interval RotationInterval
+
+	// MaxBackups is the number of rotated files kept, 0 keeps all of them
+	MaxBackups int
+
+	// MaxAge removes rotated files older than this, 0 keeps them regardless of age
+	MaxAge time.Duration
+
+	// Compress gzips rotated files
+	Compress bool
+
+	// LocalTime uses local time for rotation boundaries and backup names instead of UTC
+	LocalTime bool
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+	next time.Time
+
+	// mill compresses and removes backups in the background
+	millMu sync.Mutex
+	mills  sync.WaitGroup
+
+	// now returns the current time, it is replaced in tests
+	now func() time.Time
+}
+
+// Write writes p to the file, rotating it first when p does not fit in MaxSize or the
+// rotation interval has passed. A write larger than MaxSize goes to a file of its own.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		err := f.open()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	rotate := !f.next.IsZero() && !f.currentTime().Before(f.next)
+	if f.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.MaxSize {
+		rotate = true
+	}
+	if rotate {
+		err := f.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// Rotate closes the current file, moves it aside and opens a new one.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		err := f.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	return f.rotate()
+}
+
+// Close closes the file and waits for pending compression and cleanup of backups.
+// Writing after Close opens the file again.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.mu.Unlock()
+
+	f.mills.Wait()
+
+	return err
+}
+
+// open opens Filename for appending, continuing an existing file. When the existing file
+// was last written before the current rotation period it is rotated on the next write.
+func (f *RotatingFile) open() error {
+	err := os.MkdirAll(filepath.Dir(f.Filename), 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	started := f.currentTime()
+	if info.Size() > 0 {
+		started = info.ModTime().In(started.Location())
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.next = f.nextRotation(started)
+
+	return nil
+}
+
+// rotate moves the open file to a backup name and opens a new file in its place.
+func (f *RotatingFile) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(f.Filename, f.backupName(f.currentTime()))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	f.mills.Add(1)
+	go f.mill()
+
+	return f.open()
+}
+
+// backupName returns an unused name for a backup rotated at t.
+func (f *RotatingFile) backupName(t time.Time) string {
+	dir, prefix, ext := f.nameParts()
+
+	for {
+		name := filepath.Join(dir, prefix+t.Format(backupTimeFormat)+ext)
+		_, err := os.Lstat(name)
+		if errors.Is(err, os.ErrNotExist) {
+			_, err = os.Lstat(name + compressSuffix)
+			if errors.Is(err, os.ErrNotExist) {
+				return name
+			}
+		}
+
+		// rotated more than once in the same millisecond
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// nameParts splits Filename into its directory, the prefix of backup names and the extension.
+func (f *RotatingFile) nameParts() (dir string, prefix string, ext string) {
+	dir = filepath.Dir(f.Filename)
+	base := filepath.Base(f.Filename)
+	ext = filepath.Ext(base)
+
+	return dir, strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// nextRotation returns when a file started at t has to be rotated, zero without an interval.
+func (f *RotatingFile) nextRotation(t time.Time) time.Time {
+	switch f.Interval {
+	case RotateHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+	case RotateDaily:
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+func (f *RotatingFile) currentTime() time.Time {
+	now := time.Now
+	if f.now != nil {
+		now = f.now
+	}
+
+	if f.LocalTime {
+		return now().Local()
+	}
+	return now().UTC()
+}
+
+// backup is a rotated file found next to Filename.
+type backup struct {
+	name    string
+	modTime time.Time
+}
+
+// mill compresses new backups and removes those exceeding MaxBackups or MaxAge.
+func (f *RotatingFile) mill() {
+	defer f.mills.Done()
+
+	f.millMu.Lock()
+	defer f.millMu.Unlock()
+
+	backups, err := f.backups()
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Time{}
+	if f.MaxAge > 0 {
+		cutoff = f.currentTime().Add(-f.MaxAge)
+	}
+
+	// backups are sorted newest first
+	for i, b := range backups {
+		expired := (f.MaxBackups > 0 && i >= f.MaxBackups) || (!cutoff.IsZero() && b.modTime.Before(cutoff))
+		if expired {
+			os.Remove(b.name)
+			continue
+		}
+
+		if f.Compress && !strings.HasSuffix(b.name, compressSuffix) {
+			compressFile(b.name)
+		}
+	}
+}
+
+// backups returns the rotated files of Filename, newest first.
+func (f *RotatingFile) backups() ([]backup, error) {
+	dir, prefix, ext := f.nameParts()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		stamp := strings.TrimPrefix(strings.TrimSuffix(name, compressSuffix), prefix)
+		if !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		_, err := time.Parse(backupTimeFormat, strings.TrimSuffix(stamp, ext))
+		if err != nil {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, backup{name: filepath.Join(dir, name), modTime: info.ModTime()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return filepath.Base(backups[i].name) > filepath.Base(backups[j].name)
+	})
+
+	return backups, nil
+}
+
+// compressFile gzips name to name.gz and removes name, keeping its mode and times.
+func compressFile(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(name+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(name + compressSuffix)
+		return err
+	}
+
+	os.Chtimes(name+compressSuffix, info.ModTime(), info.ModTime())
+
+	return os.Remove(name)
+}
@@ -0,0 +1,204 @@
+package logrus
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func readBackups(t *testing.T, f *RotatingFile) []string {
+	t.Helper()
+
+	backups, err := f.backups()
+	if err != nil {
+		t.Fatalf("backups() error = %v", err)
+	}
+
+	var contents []string
+	for _, b := range backups {
+		file, err := os.Open(b.name)
+		if err != nil {
+			t.Fatalf("Open(%s) error = %v", b.name, err)
+		}
+
+		var r io.Reader = file
+		if strings.HasSuffix(b.name, compressSuffix) {
+			r, err = gzip.NewReader(file)
+			if err != nil {
+				t.Fatalf("gzip.NewReader(%s) error = %v", b.name, err)
+			}
+		}
+
+		data, err := io.ReadAll(r)
+		file.Close()
+		if err != nil {
+			t.Fatalf("ReadAll(%s) error = %v", b.name, err)
+		}
+		contents = append(contents, string(data))
+	}
+
+	return contents
+}
+
+func TestRotatingFileSize(t *testing.T) {
+	f := &RotatingFile{
+		Filename:   filepath.Join(t.TempDir(), "logs", "app.log"),
+		MaxSize:    10,
+		MaxBackups: 2,
+	}
+
+	for _, line := range []string{"line one\n", "line two\n", "line three\n", "line four\n"} {
+		_, err := f.Write([]byte(line))
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	current, err := os.ReadFile(f.Filename)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(current) != "line four\n" {
+		t.Errorf("current file = %q, want %q", current, "line four\n")
+	}
+
+	// the oldest backup was removed by MaxBackups, a line longer than MaxSize has a file of its own
+	backups := readBackups(t, f)
+	want := []string{"line three\n", "line two\n"}
+	if strings.Join(backups, "|") != strings.Join(want, "|") {
+		t.Errorf("backups = %q, want %q", backups, want)
+	}
+}
+
+func TestRotatingFileCompress(t *testing.T) {
+	f := &RotatingFile{
+		Filename: filepath.Join(t.TempDir(), "app.log"),
+		Compress: true,
+	}
+
+	f.Write([]byte("before\n"))
+	if err := f.Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	f.Write([]byte("after\n"))
+	f.Close()
+
+	backups, _ := f.backups()
+	if len(backups) != 1 || !strings.HasSuffix(backups[0].name, ".log"+compressSuffix) {
+		t.Fatalf("backups = %v, want one compressed backup", backups)
+	}
+	if contents := readBackups(t, f); contents[0] != "before\n" {
+		t.Errorf("compressed backup = %q, want %q", contents[0], "before\n")
+	}
+}
+
+func TestRotatingFileInterval(t *testing.T) {
+	now := time.Date(2024, 3, 1, 23, 59, 0, 0, time.UTC)
+
+	f := &RotatingFile{
+		Filename: filepath.Join(t.TempDir(), "app.log"),
+		Interval: RotateDaily,
+		now:      func() time.Time { return now },
+	}
+
+	f.Write([]byte("day one\n"))
+	now = now.Add(30 * time.Second)
+	f.Write([]byte("still day one\n"))
+	now = now.Add(time.Minute)
+	f.Write([]byte("day two\n"))
+	f.Close()
+
+	backups, _ := f.backups()
+	if len(backups) != 1 || filepath.Base(backups[0].name) != "app-2024-03-02T00-00-30.000.log" {
+		t.Fatalf("backups = %v, want app-2024-03-02T00-00-30.000.log", backups)
+	}
+	if contents := readBackups(t, f); contents[0] != "day one\nstill day one\n" {
+		t.Errorf("backup = %q", contents[0])
+	}
+
+	current, _ := os.ReadFile(f.Filename)
+	if string(current) != "day two\n" {
+		t.Errorf("current file = %q, want %q", current, "day two\n")
+	}
+}
+
+func TestRotatingFileMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "app-2020-01-01T00-00-00.000.log")
+	os.WriteFile(old, []byte("old\n"), 0644)
+	stamp := time.Now().Add(-48 * time.Hour)
+	os.Chtimes(old, stamp, stamp)
+
+	unrelated := filepath.Join(dir, "app-notes.log")
+	os.WriteFile(unrelated, []byte("keep\n"), 0644)
+	os.Chtimes(unrelated, stamp, stamp)
+
+	f := &RotatingFile{
+		Filename: filepath.Join(dir, "app.log"),
+		MaxAge:   24 * time.Hour,
+	}
+	f.Write([]byte("new\n"))
+	f.Rotate()
+	f.Close()
+
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Errorf("expired backup still exists, Stat() error = %v", err)
+	}
+	if _, err := os.Stat(unrelated); err != nil {
+		t.Errorf("unrelated file was removed, Stat() error = %v", err)
+	}
+	if backups := readBackups(t, f); len(backups) != 1 || backups[0] != "new\n" {
+		t.Errorf("backups = %q, want [\"new\\n\"]", backups)
+	}
+}
+
+func TestRotatingFileWithLogger(t *testing.T) {
+	f := &RotatingFile{
+		Filename: filepath.Join(t.TempDir(), "app.log"),
+		MaxSize:  512,
+	}
+
+	logger := New()
+	logger.SetOutput(f)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				logger.WithField("worker", i).Info("rotating")
+			}
+		}()
+	}
+	wg.Wait()
+	f.Close()
+
+	lines := 0
+	files := append(readBackups(t, f), "")
+	current, _ := os.ReadFile(f.Filename)
+	files[len(files)-1] = string(current)
+
+	for _, content := range files {
+		if int64(len(content)) > f.MaxSize {
+			t.Errorf("file of %d bytes exceeds MaxSize", len(content))
+		}
+		for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+			if !strings.Contains(line, "msg=rotating") {
+				t.Errorf("unexpected line %q", line)
+			}
+			lines++
+		}
+	}
+	if lines != 200 {
+		t.Errorf("found %d lines, want 200", lines)
+	}
+}
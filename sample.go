@@ -0,0 +1,155 @@
+package logrus
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// sampleBuckets is the number of counters per level, messages are hashed into them.
+const sampleBuckets = 4096
+
+// Sampling configures log sampling. Per level and message, or format string for the
+// formatted methods, the first First messages in every Tick are logged and after that
+// only every Thereafter-th message.
+type Sampling struct {
+	// Tick is the interval after which counting restarts, 1 second when 0
+	Tick time.Duration
+
+	// First is the number of messages logged in every tick before sampling starts
+	First int
+
+	// Thereafter logs every Thereafter-th message after the first First, 0 drops all of them
+	Thereafter int
+
+	// ExemptLevel and more severe levels are never sampled, fatal and panic never are
+	ExemptLevel Level
+}
+
+// sampler counts messages by hashing level and message into a fixed set of counters,
+// much like zap does, so that the decision needs no locks or allocations.
+type sampler struct {
+	tick       int64
+	first      uint64
+	thereafter uint64
+	exempt     Level
+	counters   [TraceLevel + 1][sampleBuckets]sampleCounter
+	dropped    atomic.Uint64
+}
+
+type sampleCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+// SetSampling enables sampling of repeated messages, passing nil disables it. Changing
+// the configuration resets all counters but not the number of dropped messages.
+func (logger *Logger) SetSampling(sampling *Sampling) {
+	if sampling == nil {
+		logger.sampler.Store(nil)
+		return
+	}
+
+	tick := sampling.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+
+	s := &sampler{
+		tick:   int64(tick),
+		exempt: sampling.ExemptLevel,
+	}
+	if sampling.First > 0 {
+		s.first = uint64(sampling.First)
+	}
+	if sampling.Thereafter > 0 {
+		s.thereafter = uint64(sampling.Thereafter)
+	}
+	if previous := logger.sampler.Load(); previous != nil {
+		s.dropped.Store(previous.dropped.Load())
+	}
+
+	logger.sampler.Store(s)
+}
+
+// SetSampling enables sampling on the standard logger.
+func SetSampling(sampling *Sampling) {
+	standardLogger.SetSampling(sampling)
+}
+
+// SampledDropped returns the number of messages dropped by sampling.
+func (logger *Logger) SampledDropped() uint64 {
+	s := logger.sampler.Load()
+	if s == nil {
+		return 0
+	}
+	return s.dropped.Load()
+}
+
+// sample reports whether a message with the given key should be logged.
+func (logger *Logger) sample(level Level, key string) bool {
+	s := logger.sampler.Load()
+	if s == nil {
+		return true
+	}
+	return s.sample(level, key)
+}
+
+// sampleArgs is like sample for the unformatted arguments of a message, they are only
+// formatted when they are not a single string.
+func (logger *Logger) sampleArgs(level Level, args []any) bool {
+	s := logger.sampler.Load()
+	if s == nil {
+		return true
+	}
+
+	if len(args) == 1 {
+		if msg, ok := args[0].(string); ok {
+			return s.sample(level, msg)
+		}
+	}
+
+	return s.sample(level, fmt.Sprint(args...))
+}
+
+func (s *sampler) sample(level Level, key string) bool {
+	if level <= s.exempt || level <= FatalLevel || level > TraceLevel {
+		return true
+	}
+
+	counter := &s.counters[level][fnv32a(key)%sampleBuckets]
+
+	n := counter.inc(time.Now().UnixNano(), s.tick)
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		return true
+	}
+
+	s.dropped.Add(1)
+
+	return false
+}
+
+// inc increments the counter, restarting the count when the tick has passed.
+func (c *sampleCounter) inc(now int64, tick int64) uint64 {
+	resetAt := c.resetAt.Load()
+	if resetAt > now {
+		return c.count.Add(1)
+	}
+
+	c.count.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, now+tick) {
+		return c.count.Add(1)
+	}
+
+	return 1
+}
+
+// fnv32a hashes s using FNV-1a without allocating.
+func fnv32a(s string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h
+}
@@ -0,0 +1,107 @@
+package logrus
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetSampling(&Sampling{Tick: time.Hour, First: 3, Thereafter: 5})
+
+	for i := 0; i < 20; i++ {
+		logger.Warn("repeated warning")
+		logger.WithField("n", i).Warnf("formatted %d", i)
+	}
+	logger.Info("other message")
+
+	output := buf.String()
+
+	// first 3 then the 8th, 13th and 18th
+	if n := strings.Count(output, "repeated warning"); n != 6 {
+		t.Errorf("Expected 6 sampled messages, got %d: %s", n, output)
+	}
+	// formatted messages are keyed by their format string
+	if n := strings.Count(output, "formatted"); n != 6 {
+		t.Errorf("Expected 6 sampled formatted messages, got %d: %s", n, output)
+	}
+	if !strings.Contains(output, "other message") {
+		t.Errorf("Expected other messages to be counted separately: %s", output)
+	}
+	if dropped := logger.SampledDropped(); dropped != 28 {
+		t.Errorf("SampledDropped() = %d, want 28", dropped)
+	}
+}
+
+func TestSamplingExemptLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetSampling(&Sampling{Tick: time.Hour, First: 1, ExemptLevel: ErrorLevel})
+
+	for i := 0; i < 5; i++ {
+		logger.Error("important")
+		logger.Info("chatty")
+	}
+
+	output := buf.String()
+	if n := strings.Count(output, "important"); n != 5 {
+		t.Errorf("Expected all exempt messages, got %d", n)
+	}
+	if n := strings.Count(output, "chatty"); n != 1 {
+		t.Errorf("Expected 1 sampled message, got %d", n)
+	}
+}
+
+func TestSamplingTick(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetSampling(&Sampling{Tick: 50 * time.Millisecond, First: 1})
+
+	logger.Info("ticking")
+	logger.Info("ticking")
+	time.Sleep(100 * time.Millisecond)
+	logger.Info("ticking")
+
+	if n := strings.Count(buf.String(), "ticking"); n != 2 {
+		t.Errorf("Expected counting to restart after the tick, got %d messages", n)
+	}
+}
+
+func TestSamplingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetSampling(&Sampling{Tick: time.Hour, First: 2})
+
+	slogger := slog.New(logger.Handler())
+	for i := 0; i < 5; i++ {
+		slogger.Info("via slog")
+	}
+
+	if n := strings.Count(buf.String(), "via slog"); n != 2 {
+		t.Errorf("Expected 2 sampled messages via the handler, got %d", n)
+	}
+
+	logger.SetSampling(nil)
+	logger.Info("via slog")
+	if n := strings.Count(buf.String(), "via slog"); n != 3 {
+		t.Errorf("Expected sampling to be disabled, got %d messages", n)
+	}
+}
+
+// BenchmarkSampledInfof benchmarks the cost of messages dropped by sampling
+func BenchmarkSampledInfof(b *testing.B) {
+	logger := NewTextLogger(io.Discard, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetSampling(&Sampling{Tick: time.Hour, First: 1})
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		logger.Infof("benchmark message %d", i)
+	}
+}
@@ -0,0 +1,137 @@
+package logrus
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// SignalLevelControl configures the signals handled by EnableSignalLevelControl, signals
+// that are nil are not handled.
+type SignalLevelControl struct {
+	// Raise makes the logger one level more verbose, SIGUSR1 when nil on unix systems
+	Raise os.Signal
+
+	// Lower makes the logger one level less verbose, SIGUSR2 when nil on unix systems
+	Lower os.Signal
+
+	// Report logs the current level
+	Report os.Signal
+
+	// Toggle switches between trace and the level the logger had before switching to trace
+	Toggle os.Signal
+}
+
+// EnableSignalLevelControl changes the level of logger when the process receives the
+// signals configured by control, by default SIGUSR1 raises the verbosity one step through
+// AllLevels and SIGUSR2 lowers it. Each change is logged at info level, also when the
+// logger is less verbose. The returned function stops handling the signals.
+func EnableSignalLevelControl(logger *Logger, control SignalLevelControl) (stop func()) {
+	if control.Raise == nil {
+		control.Raise = defaultRaiseSignal
+	}
+	if control.Lower == nil {
+		control.Lower = defaultLowerSignal
+	}
+
+	var sigs []os.Signal
+	for _, sig := range []os.Signal{control.Raise, control.Lower, control.Report, control.Toggle} {
+		if sig != nil {
+			sigs = append(sigs, sig)
+		}
+	}
+	if len(sigs) == 0 {
+		return func() {}
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, sigs...)
+
+	go func() {
+		// the level restored by Toggle
+		toggled := logger.GetLevel()
+
+		for {
+			select {
+			case sig := <-signals:
+				current := logger.GetLevel()
+
+				switch sig {
+				case control.Raise:
+					logger.changeLevel(sig, current, stepLevel(current, 1))
+				case control.Lower:
+					logger.changeLevel(sig, current, stepLevel(current, -1))
+				case control.Toggle:
+					if current == TraceLevel {
+						logger.changeLevel(sig, current, toggled)
+					} else {
+						toggled = current
+						logger.changeLevel(sig, current, TraceLevel)
+					}
+				case control.Report:
+					logger.WithField("current_level", current.String()).logNotice("Current log level")
+				}
+
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
+
+// changeLevel sets the level to level and logs the change triggered by sig.
+func (logger *Logger) changeLevel(sig os.Signal, previous Level, level Level) {
+	if level == previous {
+		return
+	}
+
+	logger.logLevelChange(previous, level, "Log level changed", Fields{"signal": sig.String()})
+}
+
+// logLevelChange sets the level to level and logs msg with fields as a notice.
+func (logger *Logger) logLevelChange(previous Level, level Level, msg string, fields Fields) {
+	logger.SetLevel(level)
+
+	logger.WithFields(fields).WithFields(Fields{
+		"previous_level": previous.String(),
+		"new_level":      level.String(),
+	}).logNotice(msg)
+}
+
+// noticeKey is the context key marking notices, entries written to the default output
+// regardless of its level.
+type noticeKey struct{}
+
+// logNotice logs msg at info level on the default output whatever the level of the logger
+// and its rules, sinks still only receive it when info is enabled on them.
+func (entry *Entry) logNotice(msg string) {
+	entry.write(context.WithValue(entry.Context, noticeKey{}, true), InfoLevel, msg)
+}
+
+// isNotice reports whether ctx belongs to an entry written with logNotice.
+func isNotice(ctx context.Context) bool {
+	return ctx != nil && ctx.Value(noticeKey{}) != nil
+}
+
+// stepLevel returns the level steps positions after level in AllLevels, limited to the
+// first and last level. Positive steps are more verbose.
+func stepLevel(level Level, steps int) Level {
+	for i, l := range AllLevels {
+		if l == level {
+			i = min(max(i+steps, 0), len(AllLevels)-1)
+			return AllLevels[i]
+		}
+	}
+
+	return level
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package logrus
+
+import "os"
+
+// SIGUSR1 and SIGUSR2 do not exist on this platform, signals have to be configured
+var (
+	defaultRaiseSignal os.Signal
+	defaultLowerSignal os.Signal
+)
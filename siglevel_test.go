@@ -0,0 +1,150 @@
+//go:build unix
+
+package logrus
+
+import (
+	"encoding/json"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestStepLevel(t *testing.T) {
+	tests := []struct {
+		level Level
+		steps int
+		want  Level
+	}{
+		{InfoLevel, 1, DebugLevel},
+		{InfoLevel, -1, WarnLevel},
+		{TraceLevel, 1, TraceLevel},
+		{PanicLevel, -1, PanicLevel},
+		{ErrorLevel, 3, DebugLevel},
+	}
+
+	for _, test := range tests {
+		if got := stepLevel(test.level, test.steps); got != test.want {
+			t.Errorf("stepLevel(%v, %d) = %v, want %v", test.level, test.steps, got, test.want)
+		}
+	}
+}
+
+func TestEnableSignalLevelControl(t *testing.T) {
+	buf := &syncBuffer{}
+	logger := New()
+	logger.SetOutput(buf)
+
+	stop := EnableSignalLevelControl(logger, SignalLevelControl{Toggle: syscall.SIGWINCH})
+	defer stop()
+
+	process, _ := os.FindProcess(os.Getpid())
+	send := func(sig os.Signal, want Level) {
+		t.Helper()
+
+		process.Signal(sig)
+
+		deadline := time.Now().Add(5 * time.Second)
+		for logger.GetLevel() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("level after %v = %v, want %v", sig, logger.GetLevel(), want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	send(syscall.SIGUSR1, DebugLevel)
+	send(syscall.SIGUSR2, InfoLevel)
+	send(syscall.SIGUSR2, WarnLevel)
+	send(syscall.SIGWINCH, TraceLevel)
+	send(syscall.SIGWINCH, WarnLevel)
+
+	// raising the level logs the change after setting it
+	deadline := time.Now().Add(5 * time.Second)
+	for strings.Count(buf.String(), "\n") < 5 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("logged %d changes, want 5:\n%s", len(lines), buf.String())
+	}
+	for _, want := range []string{`level=INFO msg="Log level changed"`, "new_level=debug", "previous_level=info", `signal="user defined signal 1"`} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("first change %q does not contain %q", lines[0], want)
+		}
+	}
+	if !strings.Contains(lines[3], "new_level=trace") || !strings.Contains(lines[3], "previous_level=warning") {
+		t.Errorf("toggle to trace logged as %q", lines[3])
+	}
+
+	stop()
+	// keep the signal from terminating the test
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, syscall.SIGUSR1)
+	defer signal.Stop(received)
+
+	process.Signal(syscall.SIGUSR1)
+	<-received
+	if logger.GetLevel() != WarnLevel {
+		t.Errorf("level changed after stop to %v", logger.GetLevel())
+	}
+}
+
+func TestSignalLevelControlBelowInfo(t *testing.T) {
+	buf := &syncBuffer{}
+	sink := &syncBuffer{}
+	logger := NewJSONLogger(buf, nil)
+	logger.SetLevel(ErrorLevel)
+	logger.AddSink(Sink{Name: "errors", Out: sink, Level: ErrorLevel})
+
+	stop := EnableSignalLevelControl(logger, SignalLevelControl{Report: syscall.SIGWINCH})
+	defer stop()
+
+	process, _ := os.FindProcess(os.Getpid())
+	waitForEntry := func(n int) map[string]any {
+		t.Helper()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for strings.Count(buf.String(), "\n") < n && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != n {
+			t.Fatalf("logged %d lines, want %d:\n%s", len(lines), n, buf.String())
+		}
+
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(lines[n-1]), &entry); err != nil {
+			t.Fatalf("line is not JSON: %q", lines[n-1])
+		}
+		return entry
+	}
+
+	// changes are logged at info while the logger is less verbose
+	process.Signal(syscall.SIGUSR1)
+	entry := waitForEntry(1)
+	if entry["level"] != "INFO" || entry["msg"] != "Log level changed" || entry["new_level"] != "warning" || entry["previous_level"] != "error" {
+		t.Errorf("raise logged as %v", entry)
+	}
+
+	process.Signal(syscall.SIGWINCH)
+	entry = waitForEntry(2)
+	if entry["level"] != "INFO" || entry["msg"] != "Current log level" || entry["current_level"] != "warning" {
+		t.Errorf("report logged as %v", entry)
+	}
+
+	process.Signal(syscall.SIGUSR2)
+	entry = waitForEntry(3)
+	if entry["level"] != "INFO" || entry["new_level"] != "error" || entry["previous_level"] != "warning" {
+		t.Errorf("lower logged as %v", entry)
+	}
+	waitForLevel(t, logger, ErrorLevel)
+
+	if sink.String() != "" {
+		t.Errorf("error sink received notices: %s", sink.String())
+	}
+}
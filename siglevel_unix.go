@@ -0,0 +1,14 @@
+//go:build unix
+
+package logrus
+
+import (
+	"os"
+	"syscall"
+)
+
+// the signals handled by EnableSignalLevelControl by default
+var (
+	defaultRaiseSignal os.Signal = syscall.SIGUSR1
+	defaultLowerSignal os.Signal = syscall.SIGUSR2
+)
@@ -0,0 +1,232 @@
+package logrus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// DefaultSink is the name of the output configured by SetOutput, SetLevel and the constructors.
+const DefaultSink = "default"
+
+// Sink is an additional output of a Logger with its own level and format. Every record
+// is passed to all outputs that have its level enabled.
+type Sink struct {
+	// Name identifies the sink for SetSinkLevel, SetSinkOutput and RemoveSink
+	Name string
+
+	// Handler writes the records of the sink, when nil a handler is created from Formatter and Out
+	Handler slog.Handler
+
+	// Formatter selects text or JSON output when Handler is nil
+	Formatter Formatter
+
+	// Out is where records are written when Handler is nil
+	Out io.Writer
+
+	// Level is the least severe level written to the sink
+	Level Level
+}
+
+// AddSink adds an output to the logger, IsLevelEnabled reports true for levels enabled on
+// any of the outputs.
+func (logger *Logger) AddSink(sink Sink) error {
+	if sink.Name == "" || sink.Name == DefaultSink {
+		return fmt.Errorf("invalid sink name %q", sink.Name)
+	}
+	if sink.Handler == nil && sink.Out == nil {
+		return fmt.Errorf("sink %q needs a Handler or Out", sink.Name)
+	}
+
+	logger.mu.Lock()
+	for _, s := range logger.sinks {
+		if s.Name == sink.Name {
+			logger.mu.Unlock()
+			return fmt.Errorf("sink %q already exists", sink.Name)
+		}
+	}
+	logger.sinks = append(logger.sinks[:len(logger.sinks):len(logger.sinks)], sink)
+	logger.mu.Unlock()
+
+	logger.rebuildHandler()
+
+	return nil
+}
+
+// AddSink adds an output to the standard logger.
+func AddSink(sink Sink) error {
+	return standardLogger.AddSink(sink)
+}
+
+// RemoveSink removes the named output added with AddSink.
+func (logger *Logger) RemoveSink(name string) error {
+	err := logger.updateSink(name, func(sinks []Sink, i int) ([]Sink, error) {
+		return append(sinks[:i:i], sinks[i+1:]...), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.rebuildHandler()
+
+	return nil
+}
+
+// SetSinkLevel sets the level of the named output, DefaultSink is the same as SetLevel.
+func (logger *Logger) SetSinkLevel(name string, level Level) error {
+	if name == DefaultSink {
+		logger.SetLevel(level)
+		return nil
+	}
+
+	err := logger.updateSink(name, func(sinks []Sink, i int) ([]Sink, error) {
+		sinks[i].Level = level
+		return sinks, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.rebuildHandler()
+
+	return nil
+}
+
+// SetSinkOutput sets the output of the named output, DefaultSink is the same as SetOutput.
+// Sinks with a custom Handler have no output that can be changed and return an error.
+func (logger *Logger) SetSinkOutput(name string, out io.Writer) error {
+	if name == DefaultSink {
+		logger.SetOutput(out)
+		return nil
+	}
+
+	err := logger.updateSink(name, func(sinks []Sink, i int) ([]Sink, error) {
+		if sinks[i].Handler != nil {
+			return nil, fmt.Errorf("sink %q uses a custom handler, its output cannot be set", name)
+		}
+		sinks[i].Out = out
+		return sinks, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.rebuildHandler()
+
+	return nil
+}
+
+// updateSink replaces the sinks with the result of update called with a copy of the
+// sinks and the index of the named sink, the sinks are unchanged when update fails.
+func (logger *Logger) updateSink(name string, update func(sinks []Sink, i int) ([]Sink, error)) error {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	for i, s := range logger.sinks {
+		if s.Name == name {
+			sinks, err := update(append([]Sink(nil), logger.sinks...), i)
+			if err != nil {
+				return err
+			}
+			logger.sinks = sinks
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown sink %q", name)
+}
+
+// sinkHandlers returns the handlers of the sinks, each only enabled at the level of its
+// sink, and updates the cached most verbose sink level.
+func (logger *Logger) sinkHandlers() []slog.Handler {
+	logger.mu.RLock()
+	defer logger.mu.RUnlock()
+
+	// PanicLevel, the zero value, is always enabled on the default output
+	var verbose Level
+	handlers := make([]slog.Handler, 0, len(logger.sinks))
+	for _, s := range logger.sinks {
+		verbose = max(verbose, s.Level)
+
+		handler := s.Handler
+		if handler == nil {
+			handler = newFormatterHandler(s.Formatter, s.Out, &slog.HandlerOptions{Level: s.Level.toSlogLevel()})
+		}
+		handlers = append(handlers, &sinkHandler{level: s.Level, next: handler})
+	}
+	logger.sinkLevel.Store(uint32(verbose))
+
+	return handlers
+}
+
+// sinkHandler limits a handler to the level of its sink.
+type sinkHandler struct {
+	level Level
+	next  slog.Handler
+}
+
+func (h *sinkHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return levelFromSlog(level) <= h.level && h.next.Enabled(ctx, level)
+}
+
+func (h *sinkHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *sinkHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sinkHandler{level: h.level, next: h.next.WithAttrs(attrs)}
+}
+
+func (h *sinkHandler) WithGroup(name string) slog.Handler {
+	return &sinkHandler{level: h.level, next: h.next.WithGroup(name)}
+}
+
+// fanoutHandler passes records to all of its handlers that have the level enabled, the
+// first handler is the default output which also receives notices.
+type fanoutHandler struct {
+	handlers []slog.Handler
+}
+
+func (h *fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for i, handler := range h.handlers {
+		if (i == 0 && isNotice(ctx)) || handler.Enabled(ctx, r.Level) {
+			err := handler.Handle(ctx, r.Clone())
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+
+	return &fanoutHandler{handlers: handlers}
+}
+
+func (h *fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+
+	return &fanoutHandler{handlers: handlers}
+}
@@ -0,0 +1,140 @@
+package logrus
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSinks(t *testing.T) {
+	var text, file bytes.Buffer
+
+	logger := New()
+	logger.SetOutput(&text)
+
+	err := logger.AddSink(Sink{Name: "file", Formatter: &JSONFormatter{}, Out: &file, Level: DebugLevel})
+	if err != nil {
+		t.Fatalf("AddSink() error = %v", err)
+	}
+
+	if !logger.IsLevelEnabled(DebugLevel) {
+		t.Error("IsLevelEnabled(DebugLevel) = false, want true with a debug sink")
+	}
+	if logger.IsLevelEnabled(TraceLevel) {
+		t.Error("IsLevelEnabled(TraceLevel) = true, want false")
+	}
+
+	logger.WithField("user", "bob").Debug("debug only")
+	logger.Info("both")
+	logger.GetSlogLogger().With("component", "db").Debug("through slog")
+
+	if strings.Contains(text.String(), "debug only") || strings.Contains(text.String(), "through slog") {
+		t.Errorf("text output contains debug entries: %s", text.String())
+	}
+	if !strings.Contains(text.String(), "msg=both") {
+		t.Errorf("text output missing info entry: %s", text.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(file.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("file sink has %d lines, want 3: %s", len(lines), file.String())
+	}
+	var first, last map[string]any
+	json.Unmarshal([]byte(lines[0]), &first)
+	json.Unmarshal([]byte(lines[2]), &last)
+	if first["msg"] != "debug only" || first["user"] != "bob" {
+		t.Errorf("first file entry = %v", first)
+	}
+	if last["component"] != "db" {
+		t.Errorf("last file entry = %v, want component=db", last)
+	}
+}
+
+func TestSinkManagement(t *testing.T) {
+	var primary, sink, moved bytes.Buffer
+
+	logger := New()
+	logger.SetOutput(&primary)
+
+	if err := logger.AddSink(Sink{Name: "extra", Out: &sink, Level: WarnLevel}); err != nil {
+		t.Fatalf("AddSink() error = %v", err)
+	}
+
+	for _, invalid := range []Sink{
+		{Name: "extra", Out: &sink},
+		{Name: DefaultSink, Out: &sink},
+		{Name: "", Out: &sink},
+		{Name: "nothing"},
+	} {
+		if err := logger.AddSink(invalid); err == nil {
+			t.Errorf("AddSink(%+v) error = nil, want an error", invalid)
+		}
+	}
+
+	logger.Info("info")
+	if sink.Len() != 0 {
+		t.Errorf("warn sink received info entry: %s", sink.String())
+	}
+
+	if err := logger.SetSinkLevel("extra", InfoLevel); err != nil {
+		t.Fatalf("SetSinkLevel() error = %v", err)
+	}
+	if err := logger.SetSinkOutput("extra", &moved); err != nil {
+		t.Fatalf("SetSinkOutput() error = %v", err)
+	}
+	if err := logger.SetSinkLevel(DefaultSink, ErrorLevel); err != nil {
+		t.Fatalf("SetSinkLevel(DefaultSink) error = %v", err)
+	}
+	if logger.Level != ErrorLevel {
+		t.Errorf("Level = %v, want %v", logger.Level, ErrorLevel)
+	}
+
+	primary.Reset()
+	logger.Info("moved")
+	if !strings.Contains(moved.String(), "msg=moved") {
+		t.Errorf("moved sink output = %q", moved.String())
+	}
+	if primary.Len() != 0 {
+		t.Errorf("primary output at error level received info entry: %s", primary.String())
+	}
+
+	if err := logger.SetSinkLevel("missing", InfoLevel); err == nil {
+		t.Error("SetSinkLevel() for an unknown sink error = nil, want an error")
+	}
+
+	if err := logger.RemoveSink("extra"); err != nil {
+		t.Fatalf("RemoveSink() error = %v", err)
+	}
+	if logger.IsLevelEnabled(InfoLevel) {
+		t.Error("IsLevelEnabled(InfoLevel) = true after removing the info sink")
+	}
+	if err := logger.RemoveSink("extra"); err == nil {
+		t.Error("RemoveSink() twice error = nil, want an error")
+	}
+}
+
+func TestSinkHandler(t *testing.T) {
+	var out bytes.Buffer
+
+	logger := New()
+	logger.SetOutput(&bytes.Buffer{})
+
+	handler := slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug - 4})
+	logger.AddSink(Sink{Name: "custom", Handler: handler, Level: DebugLevel})
+
+	logger.Trace("filtered by the sink level")
+	logger.Debug("written")
+
+	if strings.Contains(out.String(), "filtered") || !strings.Contains(out.String(), `"msg":"written"`) {
+		t.Errorf("custom handler output = %s", out.String())
+	}
+	if err := logger.SetSinkOutput("custom", &bytes.Buffer{}); err == nil {
+		t.Error("SetSinkOutput() for a custom handler error = nil, want an error")
+	}
+	logger.Debug("still written")
+	if !strings.Contains(out.String(), `"msg":"still written"`) {
+		t.Errorf("custom handler output = %s", out.String())
+	}
+}
@@ -73,6 +73,26 @@ func (level Level) toSlogLevel() slog.Level {
 	return slog.LevelInfo
 }
 
+// levelFromSlog converts a slog.Level to the closest Level.
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level <= slog.LevelDebug-4:
+		return TraceLevel
+	case level <= slog.LevelDebug:
+		return DebugLevel
+	case level <= slog.LevelInfo:
+		return InfoLevel
+	case level <= slog.LevelWarn:
+		return WarnLevel
+	case level <= slog.LevelError:
+		return ErrorLevel
+	case level <= slog.LevelError+4:
+		return FatalLevel
+	default:
+		return PanicLevel
+	}
+}
+
 // ParseLevel parses a Level string into a Level value.
 func ParseLevel(lvl string) (Level, error) {
 	switch lvl {
@@ -125,6 +145,11 @@ func SetLevel(level Level) {
 	standardLogger.SetLevel(level)
 }
 
+// GetLevel returns the logging Level of the standard logger.
+func GetLevel() Level {
+	return standardLogger.GetLevel()
+}
+
 // WithField creates an entry with a single field using the standard logger.
 func WithField(key string, value any) *Entry {
 	return standardLogger.WithField(key, value)
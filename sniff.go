@@ -0,0 +1,232 @@
+package logrus
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LevelPattern assigns Level to lines matching Pattern.
+type LevelPattern struct {
+	Pattern *regexp.Regexp
+	Level   Level
+}
+
+// LevelSniffer determines the level of lines written by other programs, for example
+// the output of a child process.
+type LevelSniffer struct {
+	// Default is the level of lines that match no other rule
+	Default Level
+
+	// Prefixes maps line prefixes such as "ERROR:" to levels, matched ignoring case,
+	// the longest matching prefix wins
+	Prefixes map[string]Level
+
+	// Patterns are checked in order when no prefix matches
+	Patterns []LevelPattern
+
+	// Structured enables parsing of lines that are already in logrus or slog text or
+	// JSON format, their level, message and fields are logged as structured data
+	Structured bool
+}
+
+// DefaultLevelSniffer returns a LevelSniffer that recognizes prefixes like "ERROR:" or
+// "[warn]" and structured lines, logging everything else at level.
+func DefaultLevelSniffer(level Level) LevelSniffer {
+	prefixes := make(map[string]Level)
+	for _, l := range AllLevels {
+		names := []string{l.String()}
+		switch l {
+		case WarnLevel:
+			names = append(names, "warn")
+		case ErrorLevel:
+			names = append(names, "err")
+		}
+
+		for _, name := range names {
+			prefixes[name+":"] = l
+			prefixes["["+name+"]"] = l
+		}
+	}
+
+	return LevelSniffer{
+		Default:    level,
+		Prefixes:   prefixes,
+		Structured: true,
+	}
+}
+
+// WriterSniff returns an io.Writer that logs each line at the level determined by sniffer.
+// Like WriterLevel the lines are logged by a goroutine, the writer should be closed when done.
+func (entry *Entry) WriterSniff(sniffer LevelSniffer) *io.PipeWriter {
+	return entry.pipeWriter(func(line string) { sniffer.log(entry, line) })
+}
+
+// WriterSniffCloser is like WriterSniff but logs every complete line before Write returns,
+// see WriterLevelCloser.
+func (entry *Entry) WriterSniffCloser(sniffer LevelSniffer) io.WriteCloser {
+	return entry.lineWriter(func(line string) { sniffer.log(entry, line) })
+}
+
+// WriterSniff returns an io.Writer that logs each line at the level determined by sniffer.
+func (logger *Logger) WriterSniff(sniffer LevelSniffer) *io.PipeWriter {
+	return NewEntry(logger).WriterSniff(sniffer)
+}
+
+// WriterSniffCloser returns a line buffered io.WriteCloser that logs each line at the
+// level determined by sniffer.
+func (logger *Logger) WriterSniffCloser(sniffer LevelSniffer) io.WriteCloser {
+	return NewEntry(logger).WriterSniffCloser(sniffer)
+}
+
+// log logs line to entry, levels from the line never cause an exit or panic.
+func (s *LevelSniffer) log(entry *Entry, line string) {
+	if s.Structured {
+		if level, msg, fields, ok := parseStructuredLine(line); ok {
+			structured := entry.WithFields(fields)
+			if ctx, ok := structured.levelContext(level); ok {
+				structured.write(ctx, level, msg)
+			}
+			return
+		}
+	}
+
+	level := s.level(line)
+	if ctx, ok := entry.levelContext(level); ok {
+		entry.write(ctx, level, line)
+	}
+}
+
+// level returns the level for line based on the prefixes and patterns.
+func (s *LevelSniffer) level(line string) Level {
+	trimmed := strings.TrimLeft(line, " \t")
+
+	matched := -1
+	level := s.Default
+	for prefix, l := range s.Prefixes {
+		if len(prefix) > matched && len(trimmed) >= len(prefix) && strings.EqualFold(trimmed[:len(prefix)], prefix) {
+			matched = len(prefix)
+			level = l
+		}
+	}
+	if matched >= 0 {
+		return level
+	}
+
+	for _, p := range s.Patterns {
+		if p.Pattern != nil && p.Pattern.MatchString(line) {
+			return p.Level
+		}
+	}
+
+	return s.Default
+}
+
+// parseStructuredLine parses a line in JSON or key=value format as written by logrus
+// and slog, lines without both a level and a message are not considered structured.
+func parseStructuredLine(line string) (Level, string, Fields, bool) {
+	line = strings.TrimSpace(line)
+
+	var values map[string]any
+	if strings.HasPrefix(line, "{") {
+		if json.Unmarshal([]byte(line), &values) != nil {
+			return 0, "", nil, false
+		}
+	} else {
+		kv, ok := parseKeyValues(line)
+		if !ok {
+			return 0, "", nil, false
+		}
+		values = make(map[string]any, len(kv))
+		for k, v := range kv {
+			values[k] = v
+		}
+	}
+
+	lvl, ok := values["level"].(string)
+	if !ok {
+		return 0, "", nil, false
+	}
+	level, ok := parseAnyLevel(lvl)
+	if !ok {
+		return 0, "", nil, false
+	}
+	msg, ok := values["msg"].(string)
+	if !ok {
+		return 0, "", nil, false
+	}
+
+	delete(values, "level")
+	delete(values, "msg")
+	delete(values, "time")
+
+	return level, msg, Fields(values), true
+}
+
+// parseAnyLevel parses logrus level names and slog level names such as "WARN" or "DEBUG-4".
+func parseAnyLevel(s string) (Level, bool) {
+	if level, err := ParseLevel(strings.ToLower(s)); err == nil {
+		return level, true
+	}
+
+	var level slog.Level
+	if level.UnmarshalText([]byte(s)) == nil {
+		return levelFromSlog(level), true
+	}
+
+	return 0, false
+}
+
+// parseKeyValues parses key=value pairs separated by spaces, values may be quoted.
+func parseKeyValues(line string) (map[string]string, bool) {
+	values := make(map[string]string)
+
+	for len(line) > 0 {
+		line = strings.TrimLeft(line, " ")
+		if line == "" {
+			break
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 || strings.ContainsAny(line[:eq], " \"") {
+			return nil, false
+		}
+		key := line[:eq]
+		line = line[eq+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			end := 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, false
+			}
+
+			unquoted, err := strconv.Unquote(line[:end+1])
+			if err != nil {
+				return nil, false
+			}
+			value = unquoted
+			line = line[end+1:]
+		} else {
+			end := strings.IndexByte(line, ' ')
+			if end < 0 {
+				end = len(line)
+			}
+			value = line[:end]
+			line = line[end:]
+		}
+
+		values[key] = value
+	}
+
+	return values, len(values) > 0
+}
@@ -0,0 +1,98 @@
+package logrus
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestWriterSniffPrefixes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	writer := logger.WithField("stream", "stderr").WriterSniffCloser(DefaultLevelSniffer(InfoLevel))
+	fmt.Fprintln(writer, "ERROR: disk full")
+	fmt.Fprintln(writer, "[warn] low memory")
+	fmt.Fprintln(writer, "debug: hidden")
+	fmt.Fprintln(writer, "plain output")
+	fmt.Fprintln(writer, "FATAL: not exiting")
+	writer.Close()
+
+	output := buf.String()
+	for _, want := range []string{
+		`level=ERROR msg="ERROR: disk full" stream=stderr`,
+		`level=WARN msg="[warn] low memory"`,
+		`level=INFO msg="plain output"`,
+		`level=ERROR+4 msg="FATAL: not exiting"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output: %s", want, output)
+		}
+	}
+	if strings.Contains(output, "hidden") {
+		t.Errorf("Expected debug line to be filtered: %s", output)
+	}
+}
+
+func TestWriterSniffPatterns(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	sniffer := LevelSniffer{
+		Default:  InfoLevel,
+		Patterns: []LevelPattern{{Pattern: regexp.MustCompile(`(?i)\bfailed\b`), Level: ErrorLevel}},
+	}
+
+	writer := logger.WriterSniffCloser(sniffer)
+	fmt.Fprintln(writer, "backup failed after 3 attempts")
+	writer.Close()
+
+	if !strings.Contains(buf.String(), `level=ERROR msg="backup failed after 3 attempts"`) {
+		t.Errorf("Expected pattern to set the level: %s", buf.String())
+	}
+}
+
+func TestWriterSniffStructured(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	writer := logger.WriterSniffCloser(DefaultLevelSniffer(InfoLevel))
+	fmt.Fprintln(writer, `time="2024-01-01T00:00:00Z" level=warning msg="child warning" component=db`)
+	fmt.Fprintln(writer, `time=2024-01-01T00:00:00.000Z level=ERROR msg="slog error" attempt=3`)
+	fmt.Fprintln(writer, `{"time":"2024-01-01T00:00:00Z","level":"error","msg":"json error","code":42}`)
+	fmt.Fprintln(writer, `level=DEBUG-4 msg=trace`)
+	writer.Close()
+
+	output := buf.String()
+	for _, want := range []string{
+		`"level":"WARN","msg":"child warning","component":"db"`,
+		`"level":"ERROR","msg":"slog error","attempt":"3"`,
+		`"level":"ERROR","msg":"json error","code":42`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output: %s", want, output)
+		}
+	}
+	if strings.Contains(output, "trace") {
+		t.Errorf("Expected trace line to be filtered: %s", output)
+	}
+}
+
+func TestParseKeyValues(t *testing.T) {
+	values, ok := parseKeyValues(`a=1 b="two words" c="esc\"aped"`)
+	if !ok {
+		t.Fatal("parseKeyValues() failed on valid input")
+	}
+	if values["a"] != "1" || values["b"] != "two words" || values["c"] != `esc"aped` {
+		t.Errorf("parseKeyValues() = %v", values)
+	}
+
+	for _, line := range []string{"plain text", `a="unterminated`, "=value"} {
+		if _, ok := parseKeyValues(line); ok {
+			t.Errorf("parseKeyValues(%q) should fail", line)
+		}
+	}
+}
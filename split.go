@@ -0,0 +1,74 @@
+package logrus
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// SplitOutput is an output that writes entries at Level and more severe to High and
+// less severe entries to Low. It can be passed to SetOutput, NewTextLogger and
+// NewJSONLogger, and as the Out of a Sink.
+type SplitOutput struct {
+	// Level is the least severe level written to High
+	Level Level
+
+	// High receives entries at Level and more severe
+	High io.Writer
+
+	// Low receives entries less severe than Level
+	Low io.Writer
+}
+
+// StdSplitOutput returns a SplitOutput writing entries at level and more severe to
+// os.Stderr and all others to os.Stdout.
+func StdSplitOutput(level Level) *SplitOutput {
+	return &SplitOutput{Level: level, High: os.Stderr, Low: os.Stdout}
+}
+
+// Write writes p to Low, it is only used by handlers that are not level aware such
+// as those passed to NewWithHandler.
+func (o *SplitOutput) Write(p []byte) (int, error) {
+	return o.Low.Write(p)
+}
+
+// splitHandler passes records to high or low depending on their level.
+type splitHandler struct {
+	formatter Formatter
+	level     Level
+	high      slog.Handler
+	low       slog.Handler
+}
+
+func newSplitHandler(formatter Formatter, out *SplitOutput, opts *slog.HandlerOptions) *splitHandler {
+	return &splitHandler{
+		formatter: formatter,
+		level:     out.Level,
+		high:      newFormatterHandler(formatter, out.High, opts),
+		low:       newFormatterHandler(formatter, out.Low, opts),
+	}
+}
+
+func (h *splitHandler) handler(level slog.Level) slog.Handler {
+	if levelFromSlog(level) <= h.level {
+		return h.high
+	}
+	return h.low
+}
+
+func (h *splitHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler(level).Enabled(ctx, level)
+}
+
+func (h *splitHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler(r.Level).Handle(ctx, r)
+}
+
+func (h *splitHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &splitHandler{formatter: h.formatter, level: h.level, high: h.high.WithAttrs(attrs), low: h.low.WithAttrs(attrs)}
+}
+
+func (h *splitHandler) WithGroup(name string) slog.Handler {
+	return &splitHandler{formatter: h.formatter, level: h.level, high: h.high.WithGroup(name), low: h.low.WithGroup(name)}
+}
@@ -0,0 +1,87 @@
+package logrus
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSplitOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		logger func(out *SplitOutput) *Logger
+		info   string
+	}{
+		{
+			name: "SetOutput",
+			logger: func(out *SplitOutput) *Logger {
+				logger := New()
+				logger.SetOutput(out)
+				return logger
+			},
+			info: "msg=info",
+		},
+		{
+			name: "NewTextLogger",
+			logger: func(out *SplitOutput) *Logger {
+				return NewTextLogger(out, nil)
+			},
+			info: "msg=info",
+		},
+		{
+			name: "NewJSONLogger",
+			logger: func(out *SplitOutput) *Logger {
+				return NewJSONLogger(out, nil)
+			},
+			info: `"msg":"info"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var high, low bytes.Buffer
+			logger := test.logger(&SplitOutput{Level: ErrorLevel, High: &high, Low: &low})
+
+			logger.Info("info")
+			logger.Warn("warn")
+			logger.WithField("k", "v").Error("error")
+			logger.SetLevel(DebugLevel)
+			logger.Debug("debug")
+
+			if !strings.Contains(low.String(), test.info) || !strings.Contains(low.String(), "warn") || !strings.Contains(low.String(), "debug") {
+				t.Errorf("low output = %s", low.String())
+			}
+			if strings.Contains(low.String(), "error") {
+				t.Errorf("low output contains the error entry: %s", low.String())
+			}
+			if strings.Count(high.String(), "\n") != 1 || !strings.Contains(high.String(), "error") {
+				t.Errorf("high output = %s", high.String())
+			}
+		})
+	}
+}
+
+func TestSplitOutputWithAttrs(t *testing.T) {
+	var high, low bytes.Buffer
+
+	logger := New()
+	logger.SetOutput(&SplitOutput{Level: WarnLevel, High: &high, Low: &low})
+
+	slogger := logger.GetSlogLogger().With("component", "db")
+	slogger.Info("connected")
+	slogger.Warn("slow query")
+
+	if !strings.Contains(low.String(), "component=db") || !strings.Contains(low.String(), "connected") {
+		t.Errorf("low output = %s", low.String())
+	}
+	if !strings.Contains(high.String(), "component=db") || !strings.Contains(high.String(), "slow query") {
+		t.Errorf("high output = %s", high.String())
+	}
+
+	if _, ok := handlerFormatter(logger.handler); !ok {
+		t.Error("split handler is not recognized as created by the package")
+	}
+	if _, ok := handlerFormatter(&fanoutHandler{}); ok {
+		t.Error("custom handler is recognized as created by the package")
+	}
+}
@@ -0,0 +1,121 @@
+package logrus
+
+import (
+	"log"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// packagePrefix is the function name prefix of this package, used to skip its frames
+// when looking for the caller of the standard library logger.
+var packagePrefix = reflect.TypeOf(stdLogWriter{}).PkgPath() + "."
+
+// stdLogWriter is an io.Writer for log.Logger that writes every line synchronously
+// to a Logger, attributing it to the code that called the log.Logger.
+type stdLogWriter struct {
+	logger        *Logger
+	level         Level
+	parsePrefixes bool
+}
+
+// StdLogger returns a *log.Logger that writes to the logger at the given level.
+// Unlike Writer it does not use a pipe or goroutine, and caller information
+// refers to the code that called the *log.Logger.
+func (logger *Logger) StdLogger(level Level) *log.Logger {
+	return log.New(&stdLogWriter{logger: logger, level: level}, "", 0)
+}
+
+// StdLoggerWithPrefixes is like StdLogger but takes the level of each message from a
+// leading prefix such as "[ERROR]" or "[warn]", using level when there is none.
+func (logger *Logger) StdLoggerWithPrefixes(level Level) *log.Logger {
+	return log.New(&stdLogWriter{logger: logger, level: level, parsePrefixes: true}, "", 0)
+}
+
+// RedirectStdLog sends the output of the standard library log package to the logger
+// at the given level, it returns a function that restores the previous configuration.
+func (logger *Logger) RedirectStdLog(level Level) func() {
+	flags := log.Flags()
+	prefix := log.Prefix()
+	out := log.Writer()
+
+	log.SetFlags(0)
+	log.SetPrefix("")
+	log.SetOutput(&stdLogWriter{logger: logger, level: level})
+
+	return func() {
+		log.SetFlags(flags)
+		log.SetPrefix(prefix)
+		log.SetOutput(out)
+	}
+}
+
+// RedirectStdLog sends the output of the standard library log package to the standard logger.
+func RedirectStdLog(level Level) func() {
+	return standardLogger.RedirectStdLog(level)
+}
+
+// Write logs p as a single message, the log package calls it once per message.
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	level := w.level
+
+	if w.parsePrefixes {
+		level, msg = parseLevelPrefix(msg, level)
+	}
+
+	pc := stdLogCallerPC()
+	ctx, ok := w.logger.callerLevelContext(backgroundContext, pc, level)
+	if !ok || !w.logger.sample(level, msg) {
+		return len(p), nil
+	}
+
+	entry := NewEntry(w.logger)
+	entry.Context = ctx
+
+	err := entry.writeRecord(level.toSlogLevel(), pc, msg)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// parseLevelPrefix returns the level named by a leading "[LEVEL]" in msg and msg with
+// the prefix removed, or def and msg unchanged when there is no valid prefix.
+func parseLevelPrefix(msg string, def Level) (Level, string) {
+	if len(msg) < 3 || msg[0] != '[' {
+		return def, msg
+	}
+
+	end := strings.IndexByte(msg, ']')
+	if end < 0 {
+		return def, msg
+	}
+
+	level, err := ParseLevel(strings.ToLower(msg[1:end]))
+	if err != nil {
+		return def, msg
+	}
+
+	return level, strings.TrimLeft(msg[end+1:], " ")
+}
+
+// stdLogCallerPC returns the pc of the first caller outside this package and the
+// standard library log package.
+func stdLogCallerPC() uintptr {
+	var pcs [16]uintptr
+	n := runtime.Callers(2, pcs[:])
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) && !strings.HasPrefix(frame.Function, "log.") {
+			// frame.PC is the call instruction, slog expects a return address like runtime.Callers gives
+			return frame.PC + 1
+		}
+		if !more {
+			return 0
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package logrus_test
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	logrus "github.com/choria-io/slogrus"
+)
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.NewTextLogger(&buf, &slog.HandlerOptions{AddSource: true})
+
+	std := logger.StdLogger(logrus.WarnLevel)
+	std.Printf("disk %s", "full")
+
+	output := buf.String()
+	if !strings.Contains(output, "level=WARN") || !strings.Contains(output, `msg="disk full"`) {
+		t.Errorf("Expected warning from std logger: %s", output)
+	}
+	if !strings.Contains(output, "stdlog_test.go") {
+		t.Errorf("Expected caller source to refer to the test: %s", output)
+	}
+}
+
+func TestStdLoggerLevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.NewTextLogger(&buf, nil)
+
+	logger.StdLogger(logrus.DebugLevel).Print("hidden")
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug std logger output to be filtered: %s", buf.String())
+	}
+}
+
+func TestStdLoggerWithPrefixes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.NewTextLogger(&buf, nil)
+
+	std := logger.StdLoggerWithPrefixes(logrus.InfoLevel)
+	std.Print("[ERROR] connection refused")
+	std.Print("[debug] hidden")
+	std.Print("[unknown] kept as is")
+
+	output := buf.String()
+	if !strings.Contains(output, `level=ERROR msg="connection refused"`) {
+		t.Errorf("Expected error level from prefix: %s", output)
+	}
+	if strings.Contains(output, "hidden") {
+		t.Errorf("Expected debug prefix to be filtered: %s", output)
+	}
+	if !strings.Contains(output, `level=INFO msg="[unknown] kept as is"`) {
+		t.Errorf("Expected unknown prefix to use default level: %s", output)
+	}
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.NewTextLogger(&buf, nil)
+
+	restore := logger.RedirectStdLog(logrus.InfoLevel)
+	log.Print("from std log")
+	restore()
+
+	if !strings.Contains(buf.String(), `msg="from std log"`) {
+		t.Errorf("Expected std log output in logger: %s", buf.String())
+	}
+}
+
+func TestStdLoggerSampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.NewTextLogger(&buf, nil)
+	logger.SetSampling(&logrus.Sampling{Tick: time.Hour, First: 1})
+
+	std := logger.StdLogger(logrus.WarnLevel)
+	for i := 0; i < 5; i++ {
+		std.Print("connection reset")
+	}
+
+	if n := strings.Count(buf.String(), "connection reset"); n != 1 {
+		t.Errorf("Expected 1 sampled message, got %d: %s", n, buf.String())
+	}
+	if dropped := logger.SampledDropped(); dropped != 4 {
+		t.Errorf("SampledDropped() = %d, want 4", dropped)
+	}
+}
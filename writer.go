@@ -0,0 +1,194 @@
+package logrus
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+	"unicode/utf8"
+)
+
+// LongLineMode controls how writers handle lines longer than the configured maximum.
+type LongLineMode int
+
+const (
+	// LongLineSplit logs long lines in chunks of the maximum size, all but the last
+	// chunk end with a continuation marker.
+	LongLineSplit LongLineMode = iota
+	// LongLineTruncate logs the first chunk of long lines followed by a truncation
+	// marker and discards the rest of the line.
+	LongLineTruncate
+	// LongLineUnlimited buffers lines of any length.
+	LongLineUnlimited
+)
+
+// DefaultWriterMaxLine is the default maximum line size of writers.
+const DefaultWriterMaxLine = bufio.MaxScanTokenSize
+
+const (
+	continuedMarker = " [continued]"
+	truncatedMarker = " [truncated]"
+)
+
+// SetWriterLineLimit sets how writers created after the call handle lines longer than
+// size bytes. A size of 0 or less uses DefaultWriterMaxLine.
+func (logger *Logger) SetWriterLineLimit(size int, mode LongLineMode) {
+	logger.mu.Lock()
+	logger.writerMaxLine = size
+	logger.writerLongLines = mode
+	logger.mu.Unlock()
+}
+
+// lineBuffer splits written data into lines and logs them, applying the line limit
+// of the logger it was created for.
+type lineBuffer struct {
+	print      func(line string)
+	max        int
+	mode       LongLineMode
+	buf        []byte
+	discarding bool
+}
+
+func (logger *Logger) newLineBuffer(print func(line string)) *lineBuffer {
+	logger.mu.RLock()
+	max, mode := logger.writerMaxLine, logger.writerLongLines
+	logger.mu.RUnlock()
+
+	if max <= 0 {
+		max = DefaultWriterMaxLine
+	}
+
+	return &lineBuffer{print: print, max: max, mode: mode}
+}
+
+// write logs every complete line in p and buffers the rest.
+func (b *lineBuffer) write(p []byte) {
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			b.append(p)
+			return
+		}
+
+		b.append(p[:i])
+		b.endLine()
+		p = p[i+1:]
+	}
+}
+
+// flush logs any buffered partial line.
+func (b *lineBuffer) flush() {
+	if len(b.buf) > 0 && !b.discarding {
+		b.endLine()
+	}
+	b.buf = b.buf[:0]
+	b.discarding = false
+}
+
+// append adds chunk to the current line, logging or dropping data beyond the limit.
+func (b *lineBuffer) append(chunk []byte) {
+	if b.discarding {
+		return
+	}
+
+	if b.mode == LongLineUnlimited {
+		b.buf = append(b.buf, chunk...)
+		return
+	}
+
+	for len(b.buf)+len(chunk) > b.max {
+		n := b.max - len(b.buf)
+		// Avoid cutting a multi-byte character in half, unless that makes no progress
+		m := n
+		for m > 0 && !utf8.RuneStart(chunk[m]) {
+			m--
+		}
+		if m > 0 || len(b.buf) > 0 {
+			n = m
+		}
+		b.buf = append(b.buf, chunk[:n]...)
+		chunk = chunk[n:]
+
+		if b.mode == LongLineTruncate {
+			b.print(string(b.buf) + truncatedMarker)
+			b.buf = b.buf[:0]
+			b.discarding = true
+			return
+		}
+
+		b.print(string(b.buf) + continuedMarker)
+		b.buf = b.buf[:0]
+	}
+
+	b.buf = append(b.buf, chunk...)
+}
+
+// endLine logs the current line without a trailing carriage return, like bufio.ScanLines.
+func (b *lineBuffer) endLine() {
+	if b.discarding {
+		b.discarding = false
+		b.buf = b.buf[:0]
+		return
+	}
+
+	line := b.buf
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+	b.print(string(line))
+	b.buf = b.buf[:0]
+}
+
+// lineWriter is an io.WriteCloser that logs every complete line on the goroutine
+// calling Write, any incomplete last line is logged by Close.
+type lineWriter struct {
+	mu     sync.Mutex
+	lines  *lineBuffer
+	closed bool
+}
+
+// WriterCloser returns a line buffered io.WriteCloser that writes to the logger at the info log Level.
+func (entry *Entry) WriterCloser() io.WriteCloser {
+	return entry.WriterLevelCloser(InfoLevel)
+}
+
+// WriterLevelCloser returns a line buffered io.WriteCloser that writes to the logger at the
+// given log Level. Unlike WriterLevel it does not start a goroutine, every complete line is
+// logged before Write returns and a partial last line is logged by Close.
+func (entry *Entry) WriterLevelCloser(level Level) io.WriteCloser {
+	printFunc := entry.printFunc(level)
+	return entry.lineWriter(func(line string) { printFunc(line) })
+}
+
+// lineWriter returns a lineWriter that passes each line to print.
+func (entry *Entry) lineWriter(print func(line string)) *lineWriter {
+	return &lineWriter{lines: entry.logger.newLineBuffer(print)}
+}
+
+// Write logs all complete lines in p and buffers the remainder until more data or Close.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	w.lines.write(p)
+
+	return len(p), nil
+}
+
+// Close logs any buffered partial line, further writes fail with io.ErrClosedPipe.
+func (w *lineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	w.lines.flush()
+
+	return nil
+}
@@ -5,10 +5,29 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
 
+// syncBuffer is a bytes.Buffer safe for use by background goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
 func TestLoggerWriter(t *testing.T) {
 	var buf bytes.Buffer
 	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
@@ -248,3 +267,147 @@ func TestWriterLevelFiltering(t *testing.T) {
 		t.Errorf("Warn message should be present but not found in output: %s", output)
 	}
 }
+
+func TestWriterCloser(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	writer := logger.WithField("component", "test").WriterLevelCloser(WarnLevel)
+
+	fmt.Fprint(writer, "first line\nsecond ")
+	fmt.Fprint(writer, "line\r\npartial")
+
+	// Complete lines are logged before Write returns
+	output := buf.String()
+	if !strings.Contains(output, `level=WARN msg="first line" component=test`) {
+		t.Errorf("Expected first line in output: %s", output)
+	}
+	if !strings.Contains(output, `msg="second line"`) {
+		t.Errorf("Expected second line without carriage return in output: %s", output)
+	}
+	if strings.Contains(output, "partial") {
+		t.Errorf("Partial line should be buffered until Close: %s", output)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Errorf("Close() returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "msg=partial") {
+		t.Errorf("Expected partial line to be flushed by Close: %s", buf.String())
+	}
+
+	if _, err := writer.Write([]byte("after close\n")); err == nil {
+		t.Error("Expected Write after Close to fail")
+	}
+}
+
+func TestLoggerWriterCloserLevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	debug := logger.WriterLevelCloser(DebugLevel)
+	fmt.Fprintln(debug, "debug line")
+	debug.Close()
+
+	info := logger.WriterCloser()
+	fmt.Fprintln(info, "info line")
+	info.Close()
+
+	output := buf.String()
+	if strings.Contains(output, "debug line") {
+		t.Errorf("Debug line should be filtered out: %s", output)
+	}
+	if !strings.Contains(output, "info line") {
+		t.Errorf("Expected info line in output: %s", output)
+	}
+}
+
+func TestWriterLongLines(t *testing.T) {
+	long := strings.Repeat("x", 25)
+
+	tests := []struct {
+		name string
+		mode LongLineMode
+		want []string
+	}{
+		{"split", LongLineSplit, []string{
+			`msg="xxxxxxxxxx [continued]"`,
+			`msg="xxxxxxxxxx [continued]"`,
+			`msg=xxxxx`,
+			`msg=after`,
+		}},
+		{"truncate", LongLineTruncate, []string{
+			`msg="xxxxxxxxxx [truncated]"`,
+			`msg=after`,
+		}},
+		{"unlimited", LongLineUnlimited, []string{
+			`msg=` + long,
+			`msg=after`,
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf syncBuffer
+			logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+			logger.SetWriterLineLimit(10, test.mode)
+
+			writer := logger.Writer()
+			fmt.Fprintf(writer, "%s\nafter\n", long)
+			writer.Close()
+
+			// Give some time for the goroutine to process
+			time.Sleep(100 * time.Millisecond)
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != len(test.want) {
+				t.Fatalf("Expected %d lines, got %d: %s", len(test.want), len(lines), buf.String())
+			}
+			for i, want := range test.want {
+				if !strings.Contains(lines[i], want) {
+					t.Errorf("Line %d: expected %q in %q", i, want, lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWriterCloserLongLineAcrossWrites(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger.SetWriterLineLimit(8, LongLineTruncate)
+
+	writer := logger.WriterCloser()
+	fmt.Fprint(writer, "abcdef")
+	fmt.Fprint(writer, "ghijkl")
+	fmt.Fprint(writer, "mnop\nnext\n")
+	writer.Close()
+
+	output := buf.String()
+	if !strings.Contains(output, `msg="abcdefgh [truncated]"`) {
+		t.Errorf("Expected truncated line in output: %s", output)
+	}
+	if strings.Contains(output, "ijkl") {
+		t.Errorf("Expected rest of long line to be discarded: %s", output)
+	}
+	if !strings.Contains(output, "msg=next") {
+		t.Errorf("Expected writer to keep working after a long line: %s", output)
+	}
+}
+
+func TestWriterDefaultLineLimit(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	writer := logger.WriterCloser()
+	fmt.Fprintf(writer, "%s\nafter\n", strings.Repeat("y", DefaultWriterMaxLine+10))
+	writer.Close()
+
+	output := buf.String()
+	if strings.Count(output, "[continued]") != 1 {
+		t.Errorf("Expected one continued chunk for an oversized line")
+	}
+	if !strings.Contains(output, "msg=after") {
+		t.Errorf("Expected writer to keep working after an oversized line")
+	}
+}